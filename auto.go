@@ -0,0 +1,64 @@
+package restful
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+)
+
+// autoMethodsKey keeps methods the router answers on its own in context of request.
+type autoMethodsKey struct{}
+
+type autoMethods struct {
+	head    bool
+	options bool
+}
+
+// handle answers HEAD requests with GET handlers and OPTIONS requests with list of
+// allowed methods, unless the path has handlers registered for them explicitly.
+func (auto autoMethods) handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(context.WithValue(r.Context(), autoMethodsKey{}, auto))
+
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.Routes == nil || routed(rctx.Routes, r.Method, routePath(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch {
+		case auto.head && r.Method == http.MethodHead && routed(rctx.Routes, http.MethodGet, routePath(r)):
+			rctx.RouteMethod = http.MethodGet
+			next.ServeHTTP(headWriter{w}, r)
+		case auto.options && r.Method == http.MethodOptions:
+			allowed := AllowedMethods(r)
+			if len(allowed) == 1 {
+				// only OPTIONS itself, the path is not routed at all
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("allow", strings.Join(allowed, ", "))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// headWriter drops body of GET response served for HEAD request, keeping its headers
+// including Content-Length.
+type headWriter struct {
+	http.ResponseWriter
+}
+
+func (w headWriter) Write(b []byte) (int, error) { return len(b), nil }
+
+func routePath(r *http.Request) string {
+	if r.URL.RawPath != "" {
+		return r.URL.RawPath
+	}
+
+	return r.URL.Path
+}
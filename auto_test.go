@@ -0,0 +1,100 @@
+package restful
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func autoRouter() Router {
+	router := NewRouter(chi.NewMux(), RouterOptions{AutoHead: true, AutoOptions: true})
+	router.Get("/users", func(r request.Request) response.Response {
+		res := response.Ok("test")
+		res.WithHeader("x-total-count", "1")
+		return res
+	})
+	router.Post("/users", func(r request.Request) response.Response { return response.Created() })
+	router.Route("/posts", func(r Router) {
+		r.Get("/{id}", func(r request.Request) response.Response { return response.Ok(r.Param("id")) })
+		r.Head("/{id}", func(r request.Request) response.Response {
+			res := response.NoContent()
+			res.WithHeader("x-head", "explicit")
+			return res
+		})
+		r.Delete("/{id}", func(r request.Request) response.Response { return response.NoContent() })
+	})
+
+	return router
+}
+
+func Test_AutoHead(t *testing.T) {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("HEAD", "/users", nil)
+	autoRouter().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("content-type"))
+	assert.Equal(t, "15", response.Header().Get("content-length"))
+	assert.Equal(t, "1", response.Header().Get("x-total-count"))
+	assert.Empty(t, response.Body.String())
+}
+
+func Test_AutoHead_ExplicitHandler(t *testing.T) {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("HEAD", "/posts/12", nil)
+	autoRouter().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "explicit", response.Header().Get("x-head"))
+}
+
+func Test_AutoHead_Disabled(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok("test") })
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("HEAD", "/users", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET", response.Header().Get("allow"))
+}
+
+func Test_AutoOptions(t *testing.T) {
+	tests := map[string]string{
+		"/users":    "GET, HEAD, POST, OPTIONS",
+		"/posts/12": "GET, HEAD, DELETE, OPTIONS",
+	}
+
+	for path, allow := range tests {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest("OPTIONS", path, nil)
+		autoRouter().ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, allow, response.Header().Get("allow"))
+		assert.Empty(t, response.Body.String())
+	}
+}
+
+func Test_AutoOptions_NotFound(t *testing.T) {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("OPTIONS", "/comments", nil)
+	autoRouter().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func Test_AutoMethods_MethodNotAllowed(t *testing.T) {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("PUT", "/users", nil)
+	autoRouter().ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET, HEAD, POST, OPTIONS", response.Header().Get("allow"))
+}
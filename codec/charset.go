@@ -0,0 +1,81 @@
+package codec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// windows1252 maps bytes 0x80-0x9F of Windows-1252 into runes, the rest of the charset
+// is the same as ISO-8859-1.
+var windows1252 = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+// transcoded marks readers which already produce UTF-8, so decoders don't convert
+// them again because of encoding declared inside of the document.
+type transcoded struct {
+	io.Reader
+}
+
+// Transcode converts input encoded with charset into UTF-8. Supported charsets are
+// UTF-8, US-ASCII, ISO-8859-1 and Windows-1252. Empty charset leaves input untouched,
+// so documents are free to declare their own encoding.
+func Transcode(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "":
+		return input, nil
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return transcoded{input}, nil
+	case "iso-8859-1", "iso_8859-1", "latin1", "l1":
+		return transcoded{&singleByteReader{r: bufio.NewReader(input), decode: latin1}}, nil
+	case "windows-1252", "cp1252":
+		return transcoded{&singleByteReader{r: bufio.NewReader(input), decode: cp1252}}, nil
+	default:
+		return nil, fmt.Errorf("charset '%s' is unsupported", charset)
+	}
+}
+
+func latin1(b byte) rune { return rune(b) }
+
+func cp1252(b byte) rune {
+	if b >= 0x80 && b < 0xA0 {
+		return windows1252[b-0x80]
+	}
+
+	return rune(b)
+}
+
+// singleByteReader decodes charsets mapping every byte into a single rune.
+type singleByteReader struct {
+	r       *bufio.Reader
+	decode  func(byte) rune
+	pending []byte
+}
+
+func (s *singleByteReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.pending) > 0 {
+			copied := copy(p[n:], s.pending)
+			s.pending, n = s.pending[copied:], n+copied
+			continue
+		}
+
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+
+		var encoded [utf8.UTFMax]byte
+		s.pending = encoded[:utf8.EncodeRune(encoded[:], s.decode(b))]
+	}
+
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package codec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Transcode_Latin1(t *testing.T) {
+	r, err := Transcode("ISO-8859-1", bytes.NewReader([]byte("Za\xbf\xf3\xb3\xe6")))
+	assert.NoError(t, err)
+
+	b, _ := ioutil.ReadAll(r)
+	assert.Equal(t, "Za¿ó³æ", string(b))
+}
+
+func Test_Transcode_Windows1252(t *testing.T) {
+	r, err := Transcode("windows-1252", bytes.NewReader([]byte("\x80 \x93quoted\x94")))
+	assert.NoError(t, err)
+
+	b, _ := ioutil.ReadAll(r)
+	assert.Equal(t, "€ “quoted”", string(b))
+}
+
+func Test_Transcode_Unsupported(t *testing.T) {
+	_, err := Transcode("koi8-r", strings.NewReader(""))
+
+	assert.EqualError(t, err, "charset 'koi8-r' is unsupported")
+}
+
+func Test_XML_DeclaredEncoding(t *testing.T) {
+	document := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>")
+
+	var value string
+	assert.NoError(t, XML.Decode(bytes.NewReader(document), &value))
+	assert.Equal(t, "café", value)
+}
+
+func Test_XML_TranscodedOnce(t *testing.T) {
+	document := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>")
+	r, _ := Transcode("latin1", bytes.NewReader(document))
+
+	var value string
+	assert.NoError(t, XML.Decode(r, &value))
+	assert.Equal(t, "café", value)
+}
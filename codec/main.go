@@ -0,0 +1,178 @@
+// Package codec keeps the registry of media types restful handlers are able to decode
+// request bodies from and encode responses to.
+package codec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	JSONMediaType = "application/json"
+	XMLMediaType  = "application/xml"
+)
+
+var (
+	// JSON encodes and decodes values with encoding/json.
+	JSON Codec = jsonCodec{}
+
+	// XML encodes and decodes values with encoding/xml.
+	XML Codec = xmlCodec{}
+)
+
+// Codec converts values from and to a single media type.
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+// DecodeOptions makes decoding stricter than by default.
+type DecodeOptions struct {
+	// DisallowUnknownFields rejects objects with keys not matching any field of target.
+	DisallowUnknownFields bool
+	// DisallowTrailingData rejects input with anything but whitespace after the document.
+	DisallowTrailingData bool
+	// UseNumber decodes numbers into interface{} as json.Number instead of float64.
+	UseNumber bool
+}
+
+// OptionsDecoder is implemented by codecs supporting DecodeOptions, e.g. JSON.
+type OptionsDecoder interface {
+	DecodeWithOptions(r io.Reader, v interface{}, options DecodeOptions) error
+}
+
+// errTrailingData reported when input contains more than a single document.
+var errTrailingData = errors.New("body must contain a single document")
+
+var registry = struct {
+	sync.RWMutex
+	codecs     map[string]Codec
+	mediaTypes []string
+}{codecs: map[string]Codec{}}
+
+// Register makes codec available for the given media type. Registering the same media
+// type twice replaces the previous codec. Media types are offered to clients in the
+// order of registration, JSON being the first one and the default.
+func Register(mediaType string, c Codec) {
+	mediaType = strings.ToLower(mediaType)
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.codecs[mediaType]; !ok {
+		registry.mediaTypes = append(registry.mediaTypes[:len(registry.mediaTypes):len(registry.mediaTypes)], mediaType)
+	}
+	registry.codecs[mediaType] = c
+}
+
+// Unregister removes codec of the media type, e.g. registered temporarily by tests.
+func Unregister(mediaType string) {
+	mediaType = strings.ToLower(mediaType)
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.codecs[mediaType]; !ok {
+		return
+	}
+	delete(registry.codecs, mediaType)
+
+	mediaTypes := make([]string, 0, len(registry.mediaTypes)-1)
+	for _, registered := range registry.mediaTypes {
+		if registered != mediaType {
+			mediaTypes = append(mediaTypes, registered)
+		}
+	}
+	registry.mediaTypes = mediaTypes
+}
+
+// Lookup returns codec registered for the media type. Media types with structured
+// syntax suffix (RFC 6839) fall back to codec of the suffix, so "application/vnd.api+json"
+// is handled by the JSON codec unless it has a codec of its own. Similarly "text/xml"
+// falls back to "application/xml".
+func Lookup(mediaType string) (Codec, bool) {
+	mediaType = strings.ToLower(mediaType)
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if c, ok := registry.codecs[mediaType]; ok {
+		return c, true
+	}
+
+	suffix := mediaType[strings.Index(mediaType, "/")+1:]
+	if plus := strings.LastIndex(suffix, "+"); plus >= 0 {
+		suffix = suffix[plus+1:]
+	}
+
+	c, ok := registry.codecs["application/"+suffix]
+	return c, ok
+}
+
+// MediaTypes returns all registered media types in order of registration. The list is
+// shared by all callers and must not be modified, appending to it is safe.
+func MediaTypes() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return registry.mediaTypes[:len(registry.mediaTypes):len(registry.mediaTypes)]
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+func (jsonCodec) Decode(r io.Reader, v interface{}) error { return json.NewDecoder(r).Decode(v) }
+
+func (jsonCodec) DecodeWithOptions(r io.Reader, v interface{}, options DecodeOptions) error {
+	d := json.NewDecoder(r)
+	if options.DisallowUnknownFields {
+		d.DisallowUnknownFields()
+	}
+	if options.UseNumber {
+		d.UseNumber()
+	}
+
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+
+	if options.DisallowTrailingData {
+		if _, err := d.Token(); err != io.EOF {
+			return errTrailingData
+		}
+	}
+
+	return nil
+}
+
+type xmlCodec struct{}
+
+func (xmlCodec) Encode(w io.Writer, v interface{}) error { return xml.NewEncoder(w).Encode(v) }
+
+func (xmlCodec) Decode(r io.Reader, v interface{}) error {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = Transcode
+	if _, ok := r.(transcoded); ok {
+		d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	}
+
+	return d.Decode(v)
+}
+
+func init() {
+	Register(JSONMediaType, JSON)
+	Register(XMLMediaType, XML)
+}
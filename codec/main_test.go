@@ -0,0 +1,95 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DefaultCodecs(t *testing.T) {
+	mediaTypes := MediaTypes()
+
+	assert.Equal(t, JSONMediaType, mediaTypes[0])
+	assert.Equal(t, XMLMediaType, mediaTypes[1])
+}
+
+func Test_Register(t *testing.T) {
+	Register("Text/X-Upper", upperCodec{})
+	t.Cleanup(func() { Unregister("text/x-upper") })
+
+	c, ok := Lookup("text/x-upper")
+	assert.True(t, ok)
+
+	var b bytes.Buffer
+	assert.NoError(t, c.Encode(&b, "lorem"))
+	assert.Equal(t, "LOREM", b.String())
+	assert.Contains(t, MediaTypes(), "text/x-upper")
+}
+
+func Test_Unregister(t *testing.T) {
+	Register("text/x-upper", upperCodec{})
+	mediaTypes := MediaTypes()
+	Unregister("Text/X-Upper")
+
+	_, ok := Lookup("text/x-upper")
+	assert.False(t, ok)
+	assert.Equal(t, []string{JSONMediaType, XMLMediaType}, MediaTypes())
+	assert.Equal(t, []string{JSONMediaType, XMLMediaType, "text/x-upper"}, mediaTypes)
+}
+
+func Test_MediaTypes_Append(t *testing.T) {
+	mediaTypes := append(MediaTypes(), "text/x-other")
+
+	assert.Equal(t, "text/x-other", mediaTypes[2])
+	assert.Equal(t, []string{JSONMediaType, XMLMediaType}, MediaTypes())
+}
+
+func Test_Lookup_Missing(t *testing.T) {
+	_, ok := Lookup("application/x-missing")
+
+	assert.False(t, ok)
+}
+
+func Test_JSON_RoundTrip(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, JSON.Encode(&b, map[string]int{"a": 1}))
+	assert.Equal(t, "{\"a\":1}", b.String())
+
+	value := map[string]int{}
+	assert.NoError(t, JSON.Decode(&b, &value))
+	assert.Equal(t, 1, value["a"])
+}
+
+type upperCodec struct{}
+
+func (upperCodec) Encode(w io.Writer, v interface{}) error {
+	_, err := io.WriteString(w, strings.ToUpper(v.(string)))
+	return err
+}
+
+func (upperCodec) Decode(r io.Reader, v interface{}) error {
+	b, err := ioutil.ReadAll(r)
+	*(v.(*string)) = strings.ToLower(string(b))
+	return err
+}
+
+func Test_Lookup_StructuredSuffix(t *testing.T) {
+	c, ok := Lookup("application/vnd.api+json")
+	assert.True(t, ok)
+	assert.Equal(t, JSON, c)
+
+	c, ok = Lookup("application/atom+xml")
+	assert.True(t, ok)
+	assert.Equal(t, XML, c)
+
+	c, ok = Lookup("text/xml")
+	assert.True(t, ok)
+	assert.Equal(t, XML, c)
+
+	_, ok = Lookup("application/vnd.custom+unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,22 @@
+// Package ctxvalue stores typed values in contexts under unexported keys, one key per
+// package tag and type of value. Each package in context/* passes an unexported tag type
+// of its own, so its values cannot collide with values of other packages, even of the
+// same type, nor with keys of other libraries.
+package ctxvalue
+
+import "context"
+
+type key[Tag, T any] struct{}
+
+// With returns copy of ctx carrying value for the package tagged with Tag, replacing
+// value of the same type, e.g. ctxvalue.With[tag](ctx, params).
+func With[Tag, T any](ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, key[Tag, T]{}, value)
+}
+
+// From returns value of type T carried by ctx for the package tagged with Tag, false
+// when there is none.
+func From[Tag, T any](ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(key[Tag, T]{}).(T)
+	return value, ok
+}
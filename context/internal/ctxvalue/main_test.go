@@ -0,0 +1,51 @@
+package ctxvalue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tag struct{}
+
+type otherTag struct{}
+
+type first struct{ Value int }
+
+type second struct{ Value int }
+
+func Test_With(t *testing.T) {
+	ctx := With[tag](context.Background(), first{1})
+	ctx = With[tag](ctx, second{2})
+	ctx = With[tag](ctx, first{3})
+
+	value, ok := From[tag, first](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, first{3}, value)
+
+	other, ok := From[tag, second](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, second{2}, other)
+}
+
+func Test_With_OtherTag(t *testing.T) {
+	ctx := With[tag](context.Background(), 1)
+	ctx = With[otherTag](ctx, 2)
+
+	value, ok := From[tag, int](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	other, ok := From[otherTag, int](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 2, other)
+}
+
+func Test_From_Missing(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "first", first{1})
+
+	value, ok := From[tag, first](ctx)
+	assert.False(t, ok)
+	assert.Equal(t, first{}, value)
+}
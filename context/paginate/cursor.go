@@ -0,0 +1,200 @@
+package paginate
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/context/internal/ctxvalue"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+const (
+	CursorAfter  = "after"
+	CursorBefore = "before"
+	CursorLimit  = "limit"
+)
+
+var (
+	errLimitGreaterThan0 = errors.New("param 'limit' should be greater than 0")
+	errCursorDirection   = errors.New("params 'after' and 'before' cannot be used together")
+	errCursorSecret      = errors.New("paginate: cursor secret must not be empty")
+)
+
+// CursorOptions configures cursor pagination. The same options have to be used to parse
+// cursors and to encode them.
+type CursorOptions struct {
+	// Secret signs cursors with HMAC-SHA256, so clients cannot forge them. Cursors carry
+	// the key only, so they never expire and work as both `after` and `before`, rotating
+	// the secret invalidates cursors issued before.
+	Secret []byte
+
+	// DefaultLimit of items when `limit` param is missing, DefaultTake by default.
+	DefaultLimit int
+
+	// MaxLimit rejects larger `limit` params, unlimited by default.
+	MaxLimit int
+}
+
+func (o CursorOptions) withDefaults() CursorOptions {
+	if o.DefaultLimit <= 0 {
+		o.DefaultLimit = DefaultTake
+	}
+
+	return o
+}
+
+// CursorParams pagination params parsed by Cursor. K is the key items are ordered by,
+// e.g. an id or a struct with creation time and id.
+type CursorParams[K any] struct {
+	// After key of the last item of the previous page, nil when missing.
+	After *K
+
+	// Before key of the first item of the next page, nil when missing.
+	Before *K
+
+	// Limit number of items in the page.
+	Limit int
+}
+
+// Backward reports whether items before the cursor are requested.
+func (p CursorParams[K]) Backward() bool { return p.Before != nil }
+
+// CursorFromContext returns params parsed by Cursor with the same type of key, false
+// when there are none.
+func CursorFromContext[K any](ctx context.Context) (CursorParams[K], bool) {
+	return ctxvalue.From[ctxTag, CursorParams[K]](ctx)
+}
+
+// CursorNative is Cursor working as a plain net/http middleware.
+func CursorNative[K any](options CursorOptions) func(http.Handler) http.Handler {
+	return request.HandleContext(Cursor[K](options))
+}
+
+// Cursor parses `after` and `before` cursors and `limit` of items, available to handlers
+// with CursorFromContext. Malformed or forged cursors get Bad Request. It panics when
+// secret of options is empty.
+func Cursor[K any](options CursorOptions) request.ContextHandler {
+	if len(options.Secret) == 0 {
+		panic(errCursorSecret)
+	}
+	options = options.withDefaults()
+
+	return func(req request.Request) (context.Context, response.Response) {
+		params := CursorParams[K]{}
+
+		limit, err := strconv.Atoi(req.Query(CursorLimit, fmt.Sprint(options.DefaultLimit)))
+		if err != nil {
+			return req.Context(), response.BadRequest(err)
+		}
+		if limit <= 0 {
+			return req.Context(), response.BadRequest(errLimitGreaterThan0)
+		}
+		if options.MaxLimit > 0 && limit > options.MaxLimit {
+			return req.Context(), response.BadRequest(fmt.Errorf("param 'limit' should not be greater than %d", options.MaxLimit))
+		}
+		params.Limit = limit
+
+		after, before := req.Query(CursorAfter), req.Query(CursorBefore)
+		if after != "" && before != "" {
+			return req.Context(), response.BadRequest(errCursorDirection)
+		}
+		if after != "" {
+			if params.After, err = decodeCursor[K](options.Secret, CursorAfter, after); err != nil {
+				return req.Context(), response.BadRequest(err)
+			}
+		}
+		if before != "" {
+			if params.Before, err = decodeCursor[K](options.Secret, CursorBefore, before); err != nil {
+				return req.Context(), response.BadRequest(err)
+			}
+		}
+
+		return ctxvalue.With[ctxTag](req.Context(), params), nil
+	}
+}
+
+// EncodeCursor encodes key into an opaque cursor signed with secret of options, see
+// CursorOptions.Secret.
+func EncodeCursor[K any](options CursorOptions, key K) (string, error) {
+	if len(options.Secret) == 0 {
+		return "", errCursorSecret
+	}
+
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(options.Secret, payload)), nil
+}
+
+// Cursors of pages around the current one, empty when there is no such page.
+type Cursors struct {
+	Next string
+	Prev string
+}
+
+// PageCursors encodes cursors of pages next to the page of items ordered by key, whatever
+// the direction: `after` cursor of the next page from the last item and `before` cursor
+// of the previous page from the first one. Pages shorter than the limit are the last
+// ones in their direction, the first page has no previous one.
+func PageCursors[T, K any](options CursorOptions, params CursorParams[K], items []T, key func(T) K) (Cursors, error) {
+	var cursors Cursors
+	if len(items) == 0 {
+		return cursors, nil
+	}
+
+	full := len(items) >= params.Limit
+	var err error
+	if params.Backward() || full {
+		if cursors.Next, err = EncodeCursor(options, key(items[len(items)-1])); err != nil {
+			return Cursors{}, err
+		}
+	}
+	if params.After != nil || (params.Backward() && full) {
+		if cursors.Prev, err = EncodeCursor(options, key(items[0])); err != nil {
+			return Cursors{}, err
+		}
+	}
+
+	return cursors, nil
+}
+
+func decodeCursor[K any](secret []byte, param, cursor string) (*K, error) {
+	invalid := fmt.Errorf("param '%s' is not a valid cursor", param)
+
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, invalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(secret, payload)) {
+		return nil, invalid
+	}
+
+	key := new(K)
+	if err := json.Unmarshal(payload, key); err != nil {
+		return nil, invalid
+	}
+
+	return key, nil
+}
+
+func sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,144 @@
+package paginate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+type articleKey struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+var cursorOptions = CursorOptions{Secret: []byte("secret"), MaxLimit: 50}
+
+func cursorHandler(req request.Request) response.Response {
+	params, ok := CursorFromContext[articleKey](req.Context())
+	if !ok {
+		return response.InternalServerError(errCursorSecret)
+	}
+
+	return response.Ok(params)
+}
+
+func cursorResponse(query string) *httptest.ResponseRecorder {
+	handlerToTest := CursorNative[articleKey](cursorOptions)(request.HandleAction(cursorHandler))
+
+	request, _ := http.NewRequest("GET", "/?"+query, nil)
+	response := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(response, request)
+
+	return response
+}
+
+func Test_Cursor_EmptyParams(t *testing.T) {
+	response := cursorResponse("")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "{\"data\":{\"After\":null,\"Before\":null,\"Limit\":30}}", response.Body.String())
+}
+
+func Test_Cursor_After(t *testing.T) {
+	key := articleKey{CreatedAt: time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), ID: 7}
+	cursor, err := EncodeCursor(cursorOptions, key)
+	assert.Nil(t, err)
+
+	var params CursorParams[articleKey]
+	handler := Cursor[articleKey](cursorOptions)
+	request.HandleAction(func(req request.Request) response.Response {
+		ctx, res := handler(req)
+		assert.Nil(t, res)
+		params, _ = CursorFromContext[articleKey](ctx)
+		return response.NoContent()
+	})(httptest.NewRecorder(), httptest.NewRequest("GET", "/?limit=10&after="+url.QueryEscape(cursor), nil))
+
+	assert.Equal(t, &key, params.After)
+	assert.Nil(t, params.Before)
+	assert.False(t, params.Backward())
+	assert.Equal(t, 10, params.Limit)
+}
+
+func Test_Cursor_InvalidParams(t *testing.T) {
+	cursor, _ := EncodeCursor(cursorOptions, articleKey{ID: 7})
+	forged, _ := EncodeCursor(CursorOptions{Secret: []byte("guess")}, articleKey{ID: 7})
+
+	tests := []string{
+		"limit=a",
+		"limit=0",
+		"limit=51",
+		"after=" + cursor + "&before=" + cursor,
+		"after=lorem",
+		"after=" + forged,
+		"before=" + cursor[:len(cursor)-2],
+	}
+
+	for _, query := range tests {
+		response := cursorResponse(query)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code, query)
+		assert.Equal(t, "application/problem+json", response.Header().Get("content-type"), query)
+	}
+}
+
+func Test_Cursor_EmptySecret(t *testing.T) {
+	assert.PanicsWithValue(t, errCursorSecret, func() { Cursor[int](CursorOptions{}) })
+
+	cursor, err := EncodeCursor(CursorOptions{}, 7)
+	assert.Equal(t, errCursorSecret, err)
+	assert.Empty(t, cursor)
+}
+
+func Test_CursorFromContext_OtherKey(t *testing.T) {
+	handler := CursorNative[int](cursorOptions)(request.HandleAction(cursorHandler))
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func Test_PageCursors(t *testing.T) {
+	items := []int{3, 4, 5}
+	key := func(i int) int { return i }
+	after, before := 2, 6
+
+	tests := []struct {
+		params CursorParams[int]
+		next   bool
+		prev   bool
+	}{
+		{CursorParams[int]{Limit: 3}, true, false},
+		{CursorParams[int]{Limit: 4}, false, false},
+		{CursorParams[int]{Limit: 3, After: &after}, true, true},
+		{CursorParams[int]{Limit: 4, After: &after}, false, true},
+		{CursorParams[int]{Limit: 3, Before: &before}, true, true},
+		{CursorParams[int]{Limit: 4, Before: &before}, true, false},
+	}
+
+	for _, test := range tests {
+		cursors, err := PageCursors(cursorOptions, test.params, items, key)
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.next, cursors.Next != "", test.params)
+		assert.Equal(t, test.prev, cursors.Prev != "", test.params)
+		if test.next {
+			next, _ := decodeCursor[int](cursorOptions.Secret, CursorAfter, cursors.Next)
+			assert.Equal(t, 5, *next)
+		}
+		if test.prev {
+			prev, _ := decodeCursor[int](cursorOptions.Secret, CursorBefore, cursors.Prev)
+			assert.Equal(t, 3, *prev)
+		}
+	}
+
+	cursors, err := PageCursors(cursorOptions, CursorParams[int]{Limit: 3}, []int{}, key)
+	assert.Nil(t, err)
+	assert.Equal(t, Cursors{}, cursors)
+}
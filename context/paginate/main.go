@@ -7,27 +7,53 @@ import (
 	"net/http"
 	"strconv"
 
+	"gitlab.com/devmint/go-restful/context/internal/ctxvalue"
 	"gitlab.com/devmint/go-restful/request"
 	"gitlab.com/devmint/go-restful/response"
 )
 
 const (
-	PaginateTake = "take"
-	PaginateSkip = "skip"
+	// DefaultTake and DefaultSkip are used by list routes of restful resources.
+	DefaultTake = 30
+	DefaultSkip = 0
 )
 
+const (
+	// PaginateTake and PaginateSkip name query params read by Paginate.
+	//
+	// Deprecated: Paginate no longer stores values in context under these keys, read
+	// Params with FromContext instead. Use response.TakeParam and response.SkipParam to
+	// name the query params.
+	PaginateTake = response.TakeParam
+	PaginateSkip = response.SkipParam
+)
+
+// ctxTag tags context values of the package, see ctxvalue.
+type ctxTag struct{}
+
 var (
 	errTakeGreaterThan0 = errors.New("param 'take' should be greater than 0")
 	errSkipGreaterThan0 = errors.New("param 'skip' should be greater than 0")
 )
 
+// Params pagination params parsed by Paginate.
+type Params struct {
+	Take int
+	Skip int
+}
+
+// FromContext returns params parsed by Paginate, false when there are none.
+func FromContext(ctx context.Context) (Params, bool) {
+	return ctxvalue.From[ctxTag, Params](ctx)
+}
+
 func PaginateNative(defaultTake, defaultSkip int) func(http.Handler) http.Handler {
 	return request.HandleContext(Paginate(defaultTake, defaultSkip))
 }
 
 func Paginate(defaultTake, defaultSkip int) request.ContextHandler {
 	return func(req request.Request) (context.Context, response.Response) {
-		take, skip := req.Query("take", fmt.Sprint(defaultTake)), req.Query("skip", fmt.Sprint(defaultSkip))
+		take, skip := req.Query(response.TakeParam, fmt.Sprint(defaultTake)), req.Query(response.SkipParam, fmt.Sprint(defaultSkip))
 		takeNum, err := strconv.Atoi(take)
 		if err != nil {
 			return req.Context(), response.BadRequest(err)
@@ -44,9 +70,17 @@ func Paginate(defaultTake, defaultSkip int) request.ContextHandler {
 			return req.Context(), response.BadRequest(errSkipGreaterThan0)
 		}
 
-		ctxTake := context.WithValue(req.Context(), PaginateTake, takeNum)
-		ctxSkip := context.WithValue(ctxTake, PaginateSkip, skipNum)
+		return ctxvalue.With[ctxTag](req.Context(), Params{Take: takeNum, Skip: skipNum}), nil
+	}
+}
 
-		return ctxSkip, nil
+// Page renders items of the page selected by Paginate out of total items, with links to
+// the other pages (see response.Page). Defaults are used when Paginate hasn't run.
+func Page(req request.Request, items interface{}, total int) response.Response {
+	params, ok := FromContext(req.Context())
+	if !ok {
+		params = Params{Take: DefaultTake, Skip: DefaultSkip}
 	}
+
+	return response.Page(req.Request().URL, items, params.Take, params.Skip, total)
 }
@@ -1,6 +1,7 @@
 package paginate
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,12 +52,62 @@ func Test_PaginateContext_InvalidParam(t *testing.T) {
 	handlerToTest.ServeHTTP(response, request)
 
 	assert.Equal(t, http.StatusBadRequest, response.Code)
-	assert.Equal(t, "application/json", response.Header().Get("content-type"))
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
 }
 
 func paginationHandler(req request.Request) response.Response {
-	ctx := req.Context()
-	take, skip := ctx.Value(PaginateTake).(int), ctx.Value(PaginateSkip).(int)
+	params, _ := FromContext(req.Context())
+
+	return response.Ok(map[string]int{"take": params.Take, "skip": params.Skip})
+}
+
+func Test_Page(t *testing.T) {
+	handler := request.HandleAction(func(req request.Request) response.Response {
+		return Page(req, []string{"c", "d"}, 5)
+	})
+	handlerToTest := PaginateNative(30, 0)(handler)
+
+	request, _ := http.NewRequest("GET", "/articles?take=2&skip=2", nil)
+
+	response := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "{\"data\":[\"c\",\"d\"],\"meta\":{\"take\":2,\"skip\":2,\"total\":5,\"pages\":3}}", response.Body.String())
+	assert.Contains(t, response.Header().Get("link"), "</articles?skip=4&take=2>; rel=\"next\"")
+}
+
+func Test_Page_WithoutPaginate(t *testing.T) {
+	handler := request.HandleAction(func(req request.Request) response.Response {
+		return Page(req, []string{}, 0)
+	})
+
+	request, _ := http.NewRequest("GET", "/articles", nil)
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	assert.Equal(t, "{\"data\":[],\"meta\":{\"take\":30,\"skip\":0,\"total\":0,\"pages\":0}}", response.Body.String())
+}
+
+func Test_FromContext_Missing(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "take", 12)
+
+	params, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, Params{}, params)
+}
+
+func Test_PaginateContext_NoStringKeys(t *testing.T) {
+	handler := request.HandleAction(func(req request.Request) response.Response {
+		return response.Ok(map[string]interface{}{"take": req.Context().Value("take"), "skip": req.Context().Value("skip")})
+	})
+	handlerToTest := PaginateNative(30, 0)(handler)
+
+	request, _ := http.NewRequest("GET", "/?take=12&skip=3", nil)
+
+	response := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(response, request)
 
-	return response.Ok(map[string]int{"take": take, "skip": skip})
+	assert.Equal(t, "{\"data\":{\"skip\":null,\"take\":null}}", response.Body.String())
 }
@@ -0,0 +1,120 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var (
+	errNotFound         = errors.New("requested resource does not exist")
+	errMethodNotAllowed = errors.New("requested method is not allowed for the resource")
+
+	// routedMethods are probed when looking for methods allowed on the requested path.
+	routedMethods = []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+	}
+)
+
+// fallbacks handle requests not matching any route. They are shared by all routers
+// created from the same root, so handlers set later apply to mounted routers as well.
+type fallbacks struct {
+	notFound         request.RestfulHandler
+	methodNotAllowed request.RestfulHandler
+}
+
+func newFallbacks() *fallbacks {
+	return &fallbacks{
+		notFound:         defaultNotFound,
+		methodNotAllowed: defaultMethodNotAllowed,
+	}
+}
+
+func (f *fallbacks) handleNotFound(r request.Request) response.Response {
+	return f.notFound(r)
+}
+
+func (f *fallbacks) handleMethodNotAllowed(r request.Request) response.Response {
+	return f.methodNotAllowed(r)
+}
+
+func defaultNotFound(r request.Request) response.Response {
+	return response.NotFound(errNotFound)
+}
+
+func defaultMethodNotAllowed(r request.Request) response.Response {
+	res := response.MethodNotAllowed(errMethodNotAllowed)
+	res.WithHeader("allow", strings.Join(AllowedMethods(r.Request()), ", "))
+
+	return res
+}
+
+// AllowedMethods lists methods routed for path of the request, useful for an Allow
+// header of custom MethodNotAllowed handlers. HEAD and OPTIONS are included when the
+// router answers them automatically. It returns nil for requests not served by a router.
+func AllowedMethods(r *http.Request) []string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return nil
+	}
+
+	auto, _ := r.Context().Value(autoMethodsKey{}).(autoMethods)
+	path := routePath(r)
+
+	var allowed []string
+	for _, method := range routedMethods {
+		switch {
+		case routed(rctx.Routes, method, path),
+			method == http.MethodHead && auto.head && routed(rctx.Routes, http.MethodGet, path),
+			method == http.MethodOptions && auto.options:
+			allowed = append(allowed, method)
+		}
+	}
+
+	return allowed
+}
+
+// routed reports whether method is routed for path. Unlike chi Match it looks through
+// the stubs chi registers for paths of mounted routers, which match any method, into
+// root of the mounted router.
+func routed(routes chi.Routes, method, path string) bool {
+	rctx := chi.NewRouteContext()
+	if !routes.Match(rctx, method, path) {
+		return false
+	}
+	if len(rctx.RoutePatterns) == 0 {
+		return true
+	}
+
+	matched := rctx.RoutePatterns[0]
+	for _, route := range routes.Routes() {
+		if route.SubRoutes == nil || !strings.HasSuffix(route.Pattern, "/*") {
+			continue
+		}
+
+		switch prefix := strings.TrimSuffix(route.Pattern, "/*"); matched {
+		case prefix, prefix + "/":
+			return routed(route.SubRoutes, method, "/")
+		case route.Pattern:
+			return routed(route.SubRoutes, method, "/"+wildcard(rctx))
+		}
+	}
+
+	return true
+}
+
+// wildcard returns path matched by catch-all parameter of the outermost router.
+func wildcard(rctx *chi.Context) string {
+	for i, key := range rctx.URLParams.Keys {
+		if key == "*" {
+			return rctx.URLParams.Values[i]
+		}
+	}
+
+	return ""
+}
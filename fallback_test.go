@@ -0,0 +1,141 @@
+package restful
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_Router_DefaultNotFound(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok("test") })
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/posts", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "\"status\":404")
+}
+
+func Test_Router_DefaultNotFound_XML(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok("test") })
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/posts", nil)
+	request.Header.Set("accept", "application/xml")
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "application/problem+xml", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "<status>404</status>")
+}
+
+func Test_Router_DefaultMethodNotAllowed(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok("test") })
+	router.Post("/users", func(r request.Request) response.Response { return response.Created() })
+	router.Delete("/users/{id}", func(r request.Request) response.Response { return response.NoContent() })
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("PUT", "/users", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET, POST", response.Header().Get("allow"))
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "\"status\":405")
+}
+
+func Test_Router_DefaultMethodNotAllowed_SubRouter(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Route("/users", func(r Router) {
+		r.Get("/{id}", func(r request.Request) response.Response { return response.Ok("test") })
+		r.Patch("/{id}", func(r request.Request) response.Response { return response.Ok("test") })
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/users/12", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET, PATCH", response.Header().Get("allow"))
+}
+
+func Test_Router_CustomNotFound(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Route("/users", func(r Router) {
+		r.Get("/", func(r request.Request) response.Response { return response.Ok("test") })
+	})
+	router.NotFound(func(r request.Request) response.Response {
+		return response.NotFound(errors.New("no such thing as " + r.Request().URL.Path))
+	})
+
+	for _, path := range []string{"/posts", "/users/12"} {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Contains(t, response.Body.String(), "no such thing as "+path)
+	}
+}
+
+func Test_Router_CustomMethodNotAllowed(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok("test") })
+	router.MethodNotAllowed(func(r request.Request) response.Response {
+		res := response.MethodNotAllowed(errors.New("use one of the allowed methods"))
+		res.WithHeader("allow", AllowedMethods(r.Request())[0])
+		return res
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("DELETE", "/users", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET", response.Header().Get("allow"))
+	assert.Contains(t, response.Body.String(), "use one of the allowed methods")
+}
+
+func Test_Router_DefaultMethodNotAllowed_MountedRoot(t *testing.T) {
+	handler := func(r request.Request) response.Response { return response.Ok() }
+
+	comments := NewRouter(chi.NewMux())
+	comments.Get("/", handler)
+
+	router := NewRouter(chi.NewMux())
+	router.Route("/users", func(r Router) {
+		r.Post("/", handler)
+		r.Route("/{id}/posts", func(r Router) {
+			r.Put("/", handler)
+		})
+	})
+	router.Mount("/comments", comments)
+
+	tests := map[string]string{
+		"/users":           "POST",
+		"/users/":          "POST",
+		"/users/12/posts":  "PUT",
+		"/users/12/posts/": "PUT",
+		"/comments":        "GET",
+	}
+
+	for path, allow := range tests {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest("DELETE", path, nil)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, response.Code, path)
+		assert.Equal(t, allow, response.Header().Get("allow"), path)
+	}
+}
@@ -1,10 +1,19 @@
 module gitlab.com/devmint/go-restful
 
-go 1.15
+go 1.18
 
 require (
-	github.com/devMint/go-restful v0.0.0-20210216203339-f927b1a8a23b // indirect
 	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/go-playground/validator v9.31.0+incompatible
 	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/go-playground/locales v0.13.0 // indirect
+	github.com/go-playground/universal-translator v0.17.0 // indirect
+	github.com/leodido/go-urn v1.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 )
@@ -0,0 +1,170 @@
+package restful
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/openapi"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// pathParam matches parameters of routing patterns, e.g. "{id}" or "{id:[0-9]+}".
+var pathParam = regexp.MustCompile(`\{([^}:]+)(?::([^}]+))?\}`)
+
+func (router restfulRouter) OpenAPI() *openapi.Document {
+	info := router.info
+	if info.Title == "" {
+		info.Title = "API"
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+
+	doc := openapi.New(info)
+	for _, route := range router.routes.all() {
+		path, params := pathParameters(route.pattern)
+		doc.AddOperation(route.method, path, &openapi.Operation{
+			OperationID: route.name,
+			Summary:     route.summary,
+			Parameters:  append(params, queryParameters(doc, route.query)...),
+			RequestBody: requestBody(doc, route.body),
+			Responses:   responses(doc, route),
+		})
+	}
+
+	return doc
+}
+
+// pathParameters converts routing pattern into OpenAPI path template, describing
+// regular expressions of parameters as patterns of their schemas.
+func pathParameters(pattern string) (string, []openapi.Parameter) {
+	var params []openapi.Parameter
+	for _, match := range pathParam.FindAllStringSubmatch(pattern, -1) {
+		params = append(params, openapi.Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "string", Pattern: match[2]},
+		})
+	}
+
+	return pathParam.ReplaceAllString(pattern, "{$1}"), params
+}
+
+func queryParameters(doc *openapi.Document, query interface{}) []openapi.Parameter {
+	t := reflect.TypeOf(query)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []openapi.Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup("query")
+		if !tagged && field.Anonymous {
+			params = append(params, queryParameters(doc, reflect.Zero(field.Type).Interface())...)
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if !tagged || name == "" || name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		params = append(params, openapi.Parameter{
+			Name:     name,
+			In:       "query",
+			Required: openapi.Required(field),
+			Schema:   doc.SchemaOf(reflect.Zero(field.Type).Interface()),
+		})
+	}
+
+	return params
+}
+
+func requestBody(doc *openapi.Document, body interface{}) *openapi.RequestBody {
+	if body == nil {
+		return nil
+	}
+
+	schema := doc.SchemaOf(body)
+	content := map[string]openapi.MediaType{}
+	for _, mediaType := range jsonMediaTypes() {
+		content[mediaType] = openapi.MediaType{Schema: schema}
+	}
+
+	return &openapi.RequestBody{Required: true, Content: content}
+}
+
+func responses(doc *openapi.Document, route *Route) map[string]openapi.Response {
+	if len(route.responses) == 0 {
+		return map[string]openapi.Response{
+			strconv.Itoa(http.StatusOK): {Description: http.StatusText(http.StatusOK)},
+		}
+	}
+
+	all := map[string]openapi.Response{}
+	for _, statusCode := range route.statusCodes() {
+		r := openapi.Response{Description: http.StatusText(statusCode)}
+
+		var schema *openapi.Schema
+		var sample response.Response
+		switch data := route.responses[statusCode]; {
+		case statusCode >= http.StatusBadRequest:
+			schema, sample = problemSchema(doc), response.NewProblem(statusCode)
+		case data != nil:
+			schema = &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{"data": doc.SchemaOf(data)}}
+			sample = response.Ok(data)
+		}
+
+		if schema != nil {
+			r.Content = map[string]openapi.MediaType{}
+			for _, mediaType := range jsonMediaTypes() {
+				r.Content[response.MediaType(sample, mediaType)] = openapi.MediaType{Schema: schema}
+			}
+		}
+		all[strconv.Itoa(statusCode)] = r
+	}
+
+	return all
+}
+
+// jsonMediaTypes returns registered JSON media types, the only ones described by schemas
+// derived from `json` tags.
+func jsonMediaTypes() []string {
+	var mediaTypes []string
+	for _, mediaType := range codec.MediaTypes() {
+		if mediaType == codec.JSONMediaType || strings.HasSuffix(mediaType, "+json") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+
+	return mediaTypes
+}
+
+// problemSchema describes problem details (RFC 7807) rendered for error responses.
+func problemSchema(doc *openapi.Document) *openapi.Schema {
+	if doc.Components != nil && doc.Components.Schemas["Problem"] != nil {
+		return &openapi.Schema{Ref: "#/components/schemas/Problem"}
+	}
+
+	return doc.AddSchema("Problem", &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"type":     {Type: "string"},
+			"title":    {Type: "string"},
+			"detail":   {Type: "string"},
+			"status":   {Type: "integer", Format: "int32"},
+			"instance": {Type: "string"},
+			"errors":   {Type: "array", Items: doc.SchemaOf(response.FieldError{})},
+		},
+		Required: []string{"type", "title", "status"},
+	})
+}
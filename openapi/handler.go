@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/request"
+)
+
+// yamlMediaTypes media types of YAML documents, the registered one first.
+var yamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml"}
+
+// Handler serves document returned by build, e.g. router.OpenAPI, as JSON or as YAML
+// when path of request ends with ".yaml" or ".yml", otherwise the format is negotiated
+// with the Accept header. Document is built for every request, so it describes routes
+// registered after Handler was created.
+func Handler(build func() *Document) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType, ok := yamlMediaTypes[0], true
+		if !strings.HasSuffix(r.URL.Path, ".yaml") && !strings.HasSuffix(r.URL.Path, ".yml") {
+			contentType, ok = request.Negotiate(r, append([]string{"application/json"}, yamlMediaTypes...))
+		}
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+
+		document := build()
+		render := document.JSON
+		if contentType != "application/json" {
+			render = document.YAML
+		}
+
+		body, err := render()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("content-type", contentType)
+		w.Header().Add("vary", "accept")
+		w.Write(body)
+	})
+}
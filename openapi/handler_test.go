@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Handler(t *testing.T) {
+	handler := Handler(func() *Document { return New(Info{Title: "test", Version: "1"}) })
+
+	tests := []struct {
+		path, accept, contentType string
+	}{
+		{"/openapi.json", "", "application/json"},
+		{"/openapi.yaml", "", "application/yaml"},
+		{"/openapi", "application/yaml", "application/yaml"},
+		{"/openapi", "application/json, application/yaml", "application/json"},
+		{"/openapi", "application/json;q=0.5, application/yaml", "application/yaml"},
+		{"/openapi", "text/yaml, application/*;q=0.1", "text/yaml"},
+		{"/openapi", "application/x-yaml+json", ""},
+		{"/openapi", "*/*", "application/json"},
+	}
+
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", test.path, nil)
+		request.Header.Set("accept", test.accept)
+		handler.ServeHTTP(response, request)
+
+		if test.contentType == "" {
+			assert.Equal(t, http.StatusNotAcceptable, response.Code, test.accept)
+			continue
+		}
+		assert.Equal(t, http.StatusOK, response.Code, test.accept)
+		assert.Equal(t, test.contentType, response.Header().Get("content-type"), test.accept)
+		assert.Contains(t, response.Body.String(), "3.0.3")
+	}
+}
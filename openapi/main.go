@@ -0,0 +1,277 @@
+// Package openapi describes HTTP APIs with OpenAPI 3 documents. Documents are built by
+// restful routers from registered routes, schemas are derived from Go types.
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version of OpenAPI specification documents are written in.
+const Version = "3.0.3"
+
+var (
+	// componentChars matches characters not allowed in names of components.
+	componentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Document root object of OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+
+	// types registered as schema components by SchemaOf, by names of components.
+	types map[string]reflect.Type
+}
+
+// Info metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem operations available on a single path, keyed by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a single path, query, header or cookie parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes body of request, keyed by media type.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response of operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes content of body served with a single media type.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components reusable objects referenced from the rest of document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema describes data types of bodies and parameters, a subset of JSON Schema.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// New creates empty document of the API.
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+	}
+}
+
+// AddOperation describes operation available for method on path.
+func (d *Document) AddOperation(method, path string, op *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	(*item)[strings.ToLower(method)] = op
+}
+
+// AddSchema registers reusable schema under name and returns reference to it.
+func (d *Document) AddSchema(name string, s *Schema) *Schema {
+	if d.Components == nil {
+		d.Components = &Components{Schemas: map[string]*Schema{}}
+	}
+	d.Components.Schemas[name] = s
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// SchemaOf derives schema from type of v. Named structs are registered as components
+// and referenced, so recursive types are supported. Properties are named by `json` tags
+// and fields with `validate:"required"` tag are required.
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	return d.schema(reflect.TypeOf(v))
+}
+
+func (d *Document) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+
+	s := d.typeSchema(t)
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+
+	return s
+}
+
+func (d *Document) typeSchema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() != reflect.Struct && reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		name, registered := d.componentName(t)
+		if !registered {
+			// placeholder stops recursion of self-referencing types
+			d.AddSchema(name, &Schema{})
+			d.types[name] = t
+			*d.Components.Schemas[name] = *d.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &Schema{}
+	}
+}
+
+// componentName names schema component of named type t, reporting whether it's already
+// registered. Names taken by other types, e.g. users.Item and orders.Item, are qualified
+// by package of t: with its name first and with its whole path when it's still taken.
+func (d *Document) componentName(t reflect.Type) (string, bool) {
+	if d.types == nil {
+		d.types = map[string]reflect.Type{}
+	}
+
+	qualified := t.PkgPath() + "." + t.Name()
+	candidates := []string{t.Name(), path.Base(t.PkgPath()) + "." + t.Name(), qualified}
+	for i := 2; ; i++ {
+		for _, name := range candidates {
+			name = componentChars.ReplaceAllString(name, "_")
+
+			registered, taken := d.types[name]
+			if registered == t {
+				return name, true
+			}
+			if !taken && (d.Components == nil || d.Components.Schemas[name] == nil) {
+				return name, false
+			}
+		}
+
+		// generic types may share even the qualified name once invalid characters are replaced
+		candidates = []string{qualified + "_" + strconv.Itoa(i)}
+	}
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.addFields(s, t)
+
+	return s
+}
+
+func (d *Document) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup("json")
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			d.addFields(s, fieldType)
+			continue
+		}
+		if field.PkgPath != "" || name == "-" && tag == "-" {
+			continue
+		}
+		if !tagged || name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = d.schema(field.Type)
+		if Required(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// Required reports whether field is required by its `validate` tag.
+func Required(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JSON renders document as JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Street string `json:"street" validate:"required"`
+	City   string `json:"city,omitempty"`
+}
+
+type person struct {
+	Name      string         `json:"name" validate:"required,min=3"`
+	Age       *int           `json:"age"`
+	Born      time.Time      `json:"born"`
+	Tags      []string       `json:"tags"`
+	Avatar    []byte         `json:"avatar"`
+	Labels    map[string]int `json:"labels"`
+	Address   address        `json:"address"`
+	Friends   []*person      `json:"friends"`
+	Untagged  bool
+	Skipped   string `json:"-"`
+	internal  string
+	Anonymous struct{ X float64 } `json:"anonymous"`
+}
+
+func Test_SchemaOf_Scalars(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+
+	assert.Equal(t, &Schema{Type: "string"}, doc.SchemaOf(""))
+	assert.Equal(t, &Schema{Type: "boolean"}, doc.SchemaOf(true))
+	assert.Equal(t, &Schema{Type: "integer", Format: "int64"}, doc.SchemaOf(1))
+	assert.Equal(t, &Schema{Type: "integer", Format: "int32"}, doc.SchemaOf(int32(1)))
+	assert.Equal(t, &Schema{Type: "number", Format: "double"}, doc.SchemaOf(1.5))
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, doc.SchemaOf(time.Time{}))
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, doc.SchemaOf([]string{}))
+	assert.Equal(t, &Schema{}, doc.SchemaOf(nil))
+	assert.Nil(t, doc.Components)
+}
+
+func Test_SchemaOf_Struct(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/person"}, doc.SchemaOf(&person{}))
+
+	schema := doc.Components.Schemas["person"]
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"name"}, schema.Required)
+	assert.Equal(t, &Schema{Type: "integer", Format: "int64", Nullable: true}, schema.Properties["age"])
+	assert.Equal(t, &Schema{Type: "string", Format: "byte"}, schema.Properties["avatar"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int64"}}, schema.Properties["labels"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/address"}, schema.Properties["address"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/person"}}, schema.Properties["friends"])
+	assert.Equal(t, &Schema{Type: "boolean"}, schema.Properties["Untagged"])
+	assert.Equal(t, "object", schema.Properties["anonymous"].Type)
+	assert.NotContains(t, schema.Properties, "Skipped")
+	assert.NotContains(t, schema.Properties, "internal")
+
+	assert.Equal(t, []string{"street"}, doc.Components.Schemas["address"].Required)
+}
+
+func Test_SchemaOf_NameCollision(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+	doc.AddSchema("address", &Schema{Type: "string"})
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Reader"}, doc.SchemaOf(&bytes.Reader{}))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/strings.Reader"}, doc.SchemaOf(&strings.Reader{}))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Reader"}, doc.SchemaOf(bytes.Reader{}))
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/openapi.address"}, doc.SchemaOf(address{}))
+	assert.Equal(t, &Schema{Type: "string"}, doc.Components.Schemas["address"])
+}
+
+func Test_AddOperation(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+	doc.AddOperation("GET", "/users", &Operation{Summary: "list"})
+	doc.AddOperation("POST", "/users", &Operation{Summary: "create"})
+
+	b, err := doc.JSON()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"test","version":"1"},"paths":{"/users":{"get":{"summary":"list","responses":null},"post":{"summary":"create","responses":null}}}}`, string(b))
+}
@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML renders document as YAML with the same members, in the same order, as JSON.
+func (d *Document) YAML() ([]byte, error) {
+	b, err := d.JSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, decoding it into nodes keeps order of members
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// blockStyle drops flow style and quotes of nodes decoded from JSON. Encoder still
+// quotes strings which would be read back as other types, e.g. "200" or "true".
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_YAML(t *testing.T) {
+	doc := New(Info{Title: "test: API", Version: "1.0"})
+	doc.AddOperation("GET", "/users/{id}", &Operation{
+		Summary:    "show user",
+		Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}},
+		Responses:  map[string]Response{"200": {Description: "OK"}},
+	})
+
+	b, err := doc.YAML()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `openapi: 3.0.3
+info:
+  title: 'test: API'
+  version: "1.0"
+paths:
+  /users/{id}:
+    get:
+      summary: show user
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+`, string(b))
+}
+
+func Test_YAML_SameAsJSON(t *testing.T) {
+	doc := New(Info{Title: "true", Version: "1", Description: "line\nbreak"})
+	doc.AddSchema("Empty", &Schema{Type: "object", Properties: map[string]*Schema{}})
+	doc.AddOperation("GET", "/", &Operation{Summary: "null", Responses: map[string]Response{}})
+
+	b, err := doc.YAML()
+	assert.Nil(t, err)
+	j, _ := doc.JSON()
+
+	var fromYAML, fromJSON interface{}
+	assert.Nil(t, yaml.Unmarshal(b, &fromYAML))
+	assert.Nil(t, json.Unmarshal(j, &fromJSON))
+	assert.Equal(t, fromJSON, fromYAML)
+}
@@ -0,0 +1,103 @@
+package restful
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/openapi"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+type apiUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type apiFilters struct {
+	Name string `query:"name"`
+	Take int    `query:"take" validate:"required"`
+}
+
+func apiRouter() Router {
+	handler := func(r request.Request) response.Response { return response.Ok() }
+
+	router := NewRouter(chi.NewMux(), RouterOptions{API: openapi.Info{Title: "users", Version: "2.0.0"}})
+	router.Route("/users", func(r Router) {
+		r.Get("/", handler).WithSummary("list users").WithQuery(apiFilters{}).WithResponse(http.StatusOK, []apiUser{})
+		r.Post("/", handler).WithBody(apiUser{}).WithResponse(http.StatusCreated, apiUser{}).WithResponse(http.StatusUnprocessableEntity, nil)
+		r.Get("/{id:[0-9]+}", handler)
+	})
+
+	return router
+}
+
+func Test_OpenAPI(t *testing.T) {
+	doc := apiRouter().OpenAPI()
+
+	assert.Equal(t, openapi.Info{Title: "users", Version: "2.0.0"}, doc.Info)
+	assert.Len(t, doc.Paths, 2)
+
+	list := (*doc.Paths["/users"])["get"]
+	assert.Equal(t, "list users", list.Summary)
+	assert.Equal(t, []openapi.Parameter{
+		{Name: "name", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		{Name: "take", In: "query", Required: true, Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+	}, list.Parameters)
+	assert.Equal(t, &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"data": {Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/apiUser"}}},
+	}, list.Responses["200"].Content["application/json"].Schema)
+
+	create := (*doc.Paths["/users"])["post"]
+	assert.Equal(t, &openapi.Schema{Ref: "#/components/schemas/apiUser"}, create.RequestBody.Content["application/json"].Schema)
+	assert.NotContains(t, create.RequestBody.Content, "application/xml")
+	assert.Equal(t, "Created", create.Responses["201"].Description)
+	assert.Equal(t, &openapi.Schema{Ref: "#/components/schemas/Problem"}, create.Responses["422"].Content["application/problem+json"].Schema)
+	assert.Contains(t, create.Responses["422"].Content, "application/problem+json")
+	assert.NotContains(t, create.Responses["422"].Content, "application/problem+xml")
+	assert.Contains(t, doc.Components.Schemas, "FieldError")
+
+	show := (*doc.Paths["/users/{id}"])["get"]
+	assert.Equal(t, []openapi.Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &openapi.Schema{Type: "string", Pattern: "[0-9]+"}},
+	}, show.Parameters)
+	assert.Equal(t, map[string]openapi.Response{"200": {Description: "OK"}}, show.Responses)
+}
+
+func Test_OpenAPI_MountedRouter(t *testing.T) {
+	handler := func(r request.Request) response.Response { return response.Ok() }
+
+	users := NewRouter(chi.NewMux())
+	users.Get("/", handler)
+	users.Delete("/{id}", handler)
+
+	router := NewRouter(chi.NewMux())
+	router.Group(func(r Router) {
+		r.Get("/health", handler)
+	})
+	router.Mount("/users", users)
+
+	doc := router.OpenAPI()
+
+	assert.Equal(t, openapi.Info{Title: "API", Version: "1.0.0"}, doc.Info)
+	assert.Contains(t, *doc.Paths["/health"], "get")
+	assert.Contains(t, *doc.Paths["/users"], "get")
+	assert.Contains(t, *doc.Paths["/users/{id}"], "delete")
+}
+
+func Test_OpenAPI_Handler(t *testing.T) {
+	router := apiRouter()
+	router.Mount("/openapi.yaml", openapi.Handler(router.OpenAPI))
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/openapi.yaml", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/yaml", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "summary: list users\n")
+}
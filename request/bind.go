@@ -0,0 +1,284 @@
+package request
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var (
+	errBindTarget = errors.New("binding target must be a non-nil pointer to struct")
+
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func (r nativeRequest) BindQuery(dst interface{}) error {
+	query := r.request.URL.Query()
+	return r.bind(dst, binding{
+		tagKey: "query",
+		source: "query parameter",
+		lookup: func(name string) ([]string, bool) {
+			values, ok := query[name]
+			return values, ok
+		},
+	})
+}
+
+func (r nativeRequest) BindHeaders(dst interface{}) error {
+	return r.bind(dst, binding{
+		tagKey: "header",
+		source: "header",
+		lookup: func(name string) ([]string, bool) {
+			values := r.request.Header.Values(name)
+			return values, len(values) > 0
+		},
+	})
+}
+
+func (r nativeRequest) BindCookies(dst interface{}) error {
+	cookies := r.request.Cookies()
+	return r.bind(dst, binding{
+		tagKey: "cookie",
+		source: "cookie",
+		lookup: func(name string) ([]string, bool) {
+			var values []string
+			for _, cookie := range cookies {
+				if cookie.Name == name {
+					values = append(values, cookie.Value)
+				}
+			}
+			return values, len(values) > 0
+		},
+	})
+}
+
+// binding describes where bound values come from, e.g. query string or headers.
+type binding struct {
+	// tagKey names struct tag holding name of value, e.g. `query:"name"`.
+	tagKey string
+	// source describes values in error messages, e.g. "query parameter".
+	source string
+	// lookup returns all values for the name, false when there is none.
+	lookup func(name string) ([]string, bool)
+	// files returns uploaded files for the name, nil when binding has no files.
+	files func(name string) []*UploadedFile
+}
+
+// bind fills fields of struct pointed by dst tagged with b.tagKey. Missing values are
+// replaced with the `default` tag, slices are filled from repeated and comma-separated
+// values, pointers stay nil when value is missing and time.Time is parsed with the
+// `layout` tag (time.RFC3339 by default). Bound struct is checked by validator.
+func (r nativeRequest) bind(dst interface{}, b binding) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errBindTarget
+	}
+
+	var fields []response.FieldError
+	if err := bindStruct(v.Elem(), b, &fields); err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		p := response.NewProblem(http.StatusBadRequest).WithDetail(fields[0].Message)
+		p.Errors = fields
+		return p
+	}
+
+	return validationError(r.options.Validator.Struct(dst), dst, b.tagKey)
+}
+
+// bindStruct collects values which cannot be converted into fields. Fields of types which
+// cannot be bound at all are mistakes of the handler, reported as Internal Server Error.
+func bindStruct(v reflect.Value, b binding, fields *[]response.FieldError) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup(b.tagKey)
+		if !tagged && field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+			embedded := v.Field(i)
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					if !embedded.CanSet() {
+						continue
+					}
+					embedded.Set(reflect.New(field.Type.Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if err := bindStruct(embedded, b, fields); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if !tagged || name == "" || name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		if bindFiles(v.Field(i), field, name, b, fields) {
+			continue
+		}
+
+		values, ok := b.lookup(name)
+		if !ok || isBlank(values) {
+			defaultValue, hasDefault := field.Tag.Lookup("default")
+			if !hasDefault {
+				continue
+			}
+			values = []string{defaultValue}
+		}
+
+		err := setValue(v.Field(i), values, field.Tag.Get("layout"))
+		var unsupported unsupportedTypeError
+		if errors.As(err, &unsupported) {
+			return response.NewProblem(http.StatusInternalServerError).
+				WithDetail(fmt.Sprintf("%s '%s' cannot be bound to field of type %s", b.source, name, unsupported.t))
+		}
+		if err != nil {
+			*fields = append(*fields, response.FieldError{
+				Field:   name,
+				Tag:     "type",
+				Message: fmt.Sprintf("%s '%s' must be %s", b.source, name, err.Error()),
+			})
+		}
+	}
+
+	return nil
+}
+
+// unsupportedTypeError reported by setValue for fields of types it cannot set.
+type unsupportedTypeError struct {
+	t reflect.Type
+}
+
+func (e unsupportedTypeError) Error() string {
+	return fmt.Sprintf("a value of supported type, not %s", e.t)
+}
+
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if value != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setValue converts textual values into value of field. Returned error describes
+// expected type of the value, e.g. "an integer".
+func setValue(v reflect.Value, values []string, layout string) error {
+	if v.Kind() == reflect.Ptr {
+		value := reflect.New(v.Type().Elem())
+		if err := setValue(value.Elem(), values, layout); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice && !v.Type().Implements(textUnmarshalerType) && v.Type().Elem().Kind() != reflect.Uint8 {
+		var parts []string
+		for _, value := range values {
+			for _, part := range strings.Split(value, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					parts = append(parts, part)
+				}
+			}
+		}
+
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), []string{part}, layout); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	return setScalar(v, firstValue(values), layout)
+}
+
+// firstValue returns the first non-blank value of repeated key, e.g. 5 of ?n=&n=5.
+func firstValue(values []string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return values[0]
+}
+
+func setScalar(v reflect.Value, value string, layout string) error {
+	switch v.Type() {
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return fmt.Errorf("a time in format '%s'", layout)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("a duration")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("a valid %s", v.Type().Name())
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("a non-negative integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return errors.New("a number")
+		}
+		v.SetFloat(n)
+	default:
+		return unsupportedTypeError{v.Type()}
+	}
+
+	return nil
+}
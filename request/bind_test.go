@@ -0,0 +1,159 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_BindQuery(t *testing.T) {
+	filters := listFilters{}
+	err := requestWithQuery("/?status=open&status=closed,merged&take=10&from=2021-02-16&ids=1,2,3&labels=bug&timeout=2s&internal=x").BindQuery(&filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"open", "closed", "merged"}, filters.Status)
+	assert.Equal(t, 10, filters.Take)
+	assert.Equal(t, 0, filters.Skip)
+	assert.Equal(t, time.Date(2021, 2, 16, 0, 0, 0, 0, time.UTC), filters.From)
+	assert.Equal(t, []int64{1, 2, 3}, filters.IDs)
+	assert.Equal(t, 2*time.Second, filters.Timeout)
+	assert.Nil(t, filters.Archived)
+	assert.Equal(t, []string{"bug"}, filters.Labels)
+	assert.Empty(t, filters.internal)
+}
+
+func Test_BindQuery_Defaults(t *testing.T) {
+	filters := listFilters{}
+	err := requestWithQuery("/?archived=false&take=").BindQuery(&filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30, filters.Take)
+	assert.NotNil(t, filters.Archived)
+	assert.False(t, *filters.Archived)
+	assert.Empty(t, filters.Status)
+}
+
+func Test_BindQuery_RepeatedBlank(t *testing.T) {
+	filters := listFilters{}
+	err := requestWithQuery("/?take=&take=5&from=&from=2021-02-16").BindQuery(&filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, filters.Take)
+	assert.Equal(t, time.Date(2021, 2, 16, 0, 0, 0, 0, time.UTC), filters.From)
+}
+
+func Test_BindQuery_Malformed(t *testing.T) {
+	filters := listFilters{}
+	err := requestWithQuery("/?take=ten&ids=1,a").BindQuery(&filters)
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, problem.StatusCode())
+	assert.Equal(t, "query parameter 'take' must be an integer", problem.Detail)
+	assert.Len(t, problem.Errors, 2)
+	assert.Equal(t, "ids", problem.Errors[1].Field)
+}
+
+func Test_BindQuery_Validation(t *testing.T) {
+	filters := listFilters{}
+	err := requestWithQuery("/?take=500").BindQuery(&filters)
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.StatusCode())
+	assert.Equal(t, "take", problem.Errors[0].Field)
+}
+
+func Test_BindQuery_UnsupportedField(t *testing.T) {
+	filters := struct {
+		Point complex64 `query:"point"`
+	}{}
+	err := requestWithQuery("/?point=1").BindQuery(&filters)
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, problem.StatusCode())
+	assert.Equal(t, "query parameter 'point' cannot be bound to field of type complex64", problem.Detail)
+}
+
+func Test_BindQuery_InvalidTarget(t *testing.T) {
+	filters := listFilters{}
+
+	assert.Equal(t, errBindTarget, requestWithQuery("/").BindQuery(filters))
+}
+
+func Test_BindHeaders(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("authorization", "Bearer abc")
+	r.Header.Set("x-request-id", "42")
+	r.Header.Add("accept-language", "pl, en")
+
+	headers := requestHeaders{}
+	err := wrapRequest(r, Options{}.withDefaults()).BindHeaders(&headers)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer abc", headers.Authorization)
+	assert.Equal(t, uint64(42), headers.RequestID)
+	assert.Equal(t, []string{"pl", "en"}, headers.Languages)
+}
+
+func Test_BindHeaders_Validation(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	headers := requestHeaders{}
+	err := wrapRequest(r, Options{}.withDefaults()).BindHeaders(&headers)
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.StatusCode())
+	assert.Equal(t, "Authorization", problem.Errors[0].Field)
+}
+
+func Test_BindCookies(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s3cr3t"})
+	r.AddCookie(&http.Cookie{Name: "visits", Value: "many"})
+
+	cookies := requestCookies{}
+	err := wrapRequest(r, Options{}.withDefaults()).BindCookies(&cookies)
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, "cookie 'visits' must be an integer", problem.Detail)
+	assert.Equal(t, "s3cr3t", cookies.Session)
+}
+
+type requestHeaders struct {
+	Authorization string   `header:"Authorization" validate:"required"`
+	RequestID     uint64   `header:"X-Request-ID"`
+	Languages     []string `header:"Accept-Language"`
+}
+
+type requestCookies struct {
+	Session string `cookie:"session"`
+	Visits  int    `cookie:"visits"`
+}
+
+type listFilters struct {
+	Pagination
+	Status   []string      `query:"status"`
+	From     time.Time     `query:"from" layout:"2006-01-02"`
+	IDs      []int64       `query:"ids"`
+	Archived *bool         `query:"archived"`
+	Timeout  time.Duration `query:"timeout"`
+	Labels   []string      `query:"labels" validate:"max=3"`
+	internal string        `query:"internal"`
+}
+
+type Pagination struct {
+	Take int `query:"take" default:"30" validate:"gte=1,lte=100"`
+	Skip int `query:"skip" default:"0"`
+}
+
+func requestWithQuery(url string) Request {
+	r, _ := http.NewRequest("GET", url, nil)
+	return wrapRequest(r, Options{}.withDefaults())
+}
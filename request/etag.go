@@ -0,0 +1,118 @@
+package request
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// ETagMode selects how entity tags are computed from bodies of responses.
+type ETagMode int
+
+const (
+	// ETagNone leaves responses without entity tags unless handlers set them.
+	ETagNone ETagMode = iota
+	// ETagStrong tags responses with strong entity tags, byte-for-byte identical bodies
+	// only share the tag.
+	ETagStrong
+	// ETagWeak tags responses with weak entity tags, e.g. W/"a1b2".
+	ETagWeak
+)
+
+// notModified sets validators of successful response to GET or HEAD request and reports
+// whether representation cached by client is still fresh (RFC 7232). If-None-Match takes
+// precedence over If-Modified-Since.
+func (o Options) notModified(r *http.Request, res response.Response, body []byte) bool {
+	if (r.Method != http.MethodGet && r.Method != http.MethodHead) || res.StatusCode() != http.StatusOK {
+		return false
+	}
+
+	if o.ETag != ETagNone && len(body) > 0 && res.Header().Get("etag") == "" {
+		res.Header().WithETag(computeETag(body, o.ETag == ETagWeak))
+	}
+
+	if ifNoneMatch := r.Header.Get("if-none-match"); ifNoneMatch != "" {
+		etag := res.Header().Get("etag")
+		return etag != "" && matchETag(ifNoneMatch, etag, false)
+	}
+
+	lastModified, err := http.ParseTime(res.Header().Get("last-modified"))
+	if err != nil {
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("if-modified-since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.After(ifModifiedSince)
+}
+
+// computeETag hashes body of response.
+func computeETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%x"`, sum[:16])
+	if weak {
+		etag = "W/" + etag
+	}
+
+	return etag
+}
+
+// matchETag checks entity tag against list of tags from If-Match or If-None-Match
+// header, using strong or weak comparison (RFC 7232, section 2.3.2).
+func matchETag(list string, etag string, strong bool) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+
+	for _, candidate := range parseETags(list) {
+		if strong && (strings.HasPrefix(candidate, "W/") || strings.HasPrefix(etag, "W/")) {
+			continue
+		}
+		if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseETags splits list of entity tags, commas inside quoted tags included.
+func parseETags(list string) []string {
+	var etags []string
+	for {
+		list = strings.TrimLeft(list, " \t,")
+		weak := strings.HasPrefix(list, "W/")
+		if weak {
+			list = list[2:]
+		}
+		if !strings.HasPrefix(list, `"`) {
+			return etags
+		}
+
+		end := strings.Index(list[1:], `"`)
+		if end < 0 {
+			return etags
+		}
+
+		etag := list[:end+2]
+		if weak {
+			etag = "W/" + etag
+		}
+		etags, list = append(etags, etag), list[end+2:]
+	}
+}
+
+// writeNotModified responds with 304 Not Modified, keeping validators and other headers
+// of the response the client has cached.
+func writeNotModified(w http.ResponseWriter, res response.Response) {
+	for name, value := range res.Header() {
+		w.Header().Set(name, value)
+	}
+	w.Header().Add("vary", "accept")
+	w.WriteHeader(http.StatusNotModified)
+}
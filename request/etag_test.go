@@ -0,0 +1,145 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var modifiedAt = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+func articleHandler(r Request) response.Response {
+	res := response.Ok("article")
+	res.WithHeader("cache-control", "max-age=60")
+	if r.Query("modified") != "" {
+		res.Header().WithLastModified(modifiedAt)
+	}
+	if etag := r.Query("etag"); etag != "" {
+		res.Header().WithETag(etag)
+	}
+
+	return res
+}
+
+func Test_ETag_Computed(t *testing.T) {
+	tests := map[ETagMode]string{
+		ETagStrong: `"`,
+		ETagWeak:   `W/"`,
+	}
+
+	for mode, prefix := range tests {
+		handler := HandleAction(articleHandler, Options{ETag: mode})
+
+		request, _ := http.NewRequest("GET", "/", nil)
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Regexp(t, "^"+prefix+"[0-9a-f]{32}\"$", response.Header().Get("etag"))
+		assert.Equal(t, `{"data":"article"}`, response.Body.String())
+
+		request, _ = http.NewRequest("GET", "/", nil)
+		request.Header.Set("accept", "application/xml")
+		assert.NotEqual(t, response.Header().Get("etag"), httpResponse(handler, request).Header().Get("etag"))
+	}
+}
+
+func Test_ETag_Disabled(t *testing.T) {
+	handler := HandleAction(articleHandler)
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, response.Header().Get("etag"))
+}
+
+func Test_ETag_IfNoneMatch(t *testing.T) {
+	handler := HandleAction(articleHandler, Options{ETag: ETagStrong})
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	etag := httpResponse(handler, request).Header().Get("etag")
+
+	tests := map[string]int{
+		etag:               http.StatusNotModified,
+		"W/" + etag:        http.StatusNotModified,
+		`"other", ` + etag: http.StatusNotModified,
+		"*":                http.StatusNotModified,
+		`"other"`:          http.StatusOK,
+		`"a,b", "c"`:       http.StatusOK,
+	}
+
+	for ifNoneMatch, status := range tests {
+		request, _ := http.NewRequest("GET", "/", nil)
+		request.Header.Set("if-none-match", ifNoneMatch)
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, status, response.Code, ifNoneMatch)
+		if status == http.StatusNotModified {
+			assert.Empty(t, response.Body.String())
+			assert.Equal(t, etag, response.Header().Get("etag"))
+			assert.Equal(t, "max-age=60", response.Header().Get("cache-control"))
+		}
+	}
+}
+
+func Test_ETag_FromHandler(t *testing.T) {
+	handler := HandleAction(articleHandler, Options{ETag: ETagStrong})
+
+	request, _ := http.NewRequest("GET", "/?etag=v2", nil)
+	request.Header.Set("if-none-match", `"v2"`)
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Equal(t, `"v2"`, response.Header().Get("etag"))
+}
+
+func Test_ETag_IfModifiedSince(t *testing.T) {
+	handler := HandleAction(articleHandler)
+
+	tests := map[time.Time]int{
+		modifiedAt:                   http.StatusNotModified,
+		modifiedAt.Add(time.Hour):    http.StatusNotModified,
+		modifiedAt.Add(-time.Second): http.StatusOK,
+	}
+
+	for since, status := range tests {
+		request, _ := http.NewRequest("GET", "/?modified=1", nil)
+		request.Header.Set("if-modified-since", since.Format(http.TimeFormat))
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, status, response.Code)
+		assert.Equal(t, "Wed, 03 Feb 2021 04:05:06 GMT", response.Header().Get("last-modified"))
+	}
+}
+
+func Test_ETag_IfNoneMatchPrecedence(t *testing.T) {
+	handler := HandleAction(articleHandler)
+
+	request, _ := http.NewRequest("GET", "/?modified=1&etag=v2", nil)
+	request.Header.Set("if-none-match", `"v1"`)
+	request.Header.Set("if-modified-since", modifiedAt.Format(http.TimeFormat))
+
+	assert.Equal(t, http.StatusOK, httpResponse(handler, request).Code)
+}
+
+func Test_ETag_UnsafeMethod(t *testing.T) {
+	handler := HandleAction(articleHandler, Options{ETag: ETagStrong})
+
+	request, _ := http.NewRequest("POST", "/", nil)
+	request.Header.Set("if-none-match", "*")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, response.Header().Get("etag"))
+}
+
+func Test_MatchETag(t *testing.T) {
+	assert.True(t, matchETag(`"a"`, `"a"`, true))
+	assert.False(t, matchETag(`W/"a"`, `"a"`, true))
+	assert.False(t, matchETag(`"a"`, `W/"a"`, true))
+	assert.True(t, matchETag(`W/"a"`, `"a"`, false))
+	assert.Equal(t, []string{`"a,b"`, `W/"c"`}, parseETags(` "a,b" ,W/"c", broken`))
+}
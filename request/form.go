@@ -0,0 +1,333 @@
+package request
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+const (
+	appForm      = "application/x-www-form-urlencoded"
+	appMultipart = "multipart/form-data"
+
+	// defaultMaxMultipartMemory is the same limit net/http uses for in-memory parts.
+	defaultMaxMultipartMemory = 32 << 20
+
+	// maxMultipartValues bytes of non-file fields accepted, the same as net/http does.
+	maxMultipartValues = 10 << 20
+)
+
+var (
+	uploadedFileType      = reflect.TypeOf(&UploadedFile{})
+	uploadedFileSliceType = reflect.TypeOf([]*UploadedFile{})
+)
+
+// UploadedFile file sent within multipart/form-data body. Bind it with `form` tag on
+// fields of type *UploadedFile or []*UploadedFile, limited by optional `maxsize` tag
+// (in bytes) and `accept` tag (comma-separated media types, e.g. "image/png,image/*").
+// Files are available until the handler returns.
+type UploadedFile struct {
+	// Filename name of the file as sent by client. It must not be trusted as a path.
+	Filename string
+	// Size of the file in bytes.
+	Size int64
+	// ContentType media type detected from content of the file, or declared by client
+	// when it cannot be detected.
+	ContentType string
+
+	// content of the file kept in memory, or path of temporary file it was stored in.
+	content []byte
+	path    string
+}
+
+// detectContentType replaces content type declared by client with the one detected
+// from content of the file.
+func (f *UploadedFile) detectContentType() error {
+	file, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	if detected := http.DetectContentType(sniff[:n]); detected != "application/octet-stream" || f.ContentType == "" {
+		f.ContentType = detected
+	}
+
+	return nil
+}
+
+// Open opens content of the file for reading.
+func (f *UploadedFile) Open() (multipart.File, error) {
+	if f.path != "" {
+		return os.Open(f.path)
+	}
+
+	return memoryFile{bytes.NewReader(f.content)}, nil
+}
+
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error { return nil }
+
+// WriteTo streams content of the file into w.
+func (f *UploadedFile) WriteTo(w io.Writer) (int64, error) {
+	file, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return io.Copy(w, file)
+}
+
+// SaveTemp streams content of the file into a new temporary file created in dir (see
+// ioutil.TempFile for the meaning of dir and pattern) and returns its path.
+func (f *UploadedFile) SaveTemp(dir, pattern string) (string, error) {
+	tmp, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := f.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	return tmp.Name(), tmp.Close()
+}
+
+// accepts checks limits declared in tags of the field the file is bound to.
+func (f *UploadedFile) accepts(field reflect.StructField) (string, bool) {
+	if maxSize, err := strconv.ParseInt(field.Tag.Get("maxsize"), 10, 64); err == nil && f.Size > maxSize {
+		return fmt.Sprintf("must not be larger than %d bytes", maxSize), false
+	}
+
+	accept := field.Tag.Get("accept")
+	if accept == "" {
+		return "", true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(f.ContentType)
+	for _, allowed := range strings.Split(accept, ",") {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return "", true
+		}
+	}
+
+	return fmt.Sprintf("must be one of %s", accept), false
+}
+
+// bindForm fills struct from url-encoded or multipart form, using `form` tags.
+func (r nativeRequest) bindForm(dst interface{}, multipartForm bool) error {
+	if !multipartForm {
+		if err := r.request.ParseForm(); err != nil {
+			return response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+		}
+
+		return r.bind(dst, binding{
+			tagKey: "form",
+			source: "form field",
+			lookup: func(name string) ([]string, bool) {
+				values, ok := r.request.PostForm[name]
+				return values, ok
+			},
+		})
+	}
+
+	form, err := r.readMultipart(fileLimits(reflect.TypeOf(dst)))
+	if err != nil {
+		return err
+	}
+
+	return r.bind(dst, binding{
+		tagKey: "form",
+		source: "form field",
+		lookup: func(name string) ([]string, bool) {
+			values, ok := form.values[name]
+			return values, ok
+		},
+		files: func(name string) []*UploadedFile {
+			return form.files[name]
+		},
+	})
+}
+
+// multipartForm values and files read from multipart/form-data body.
+type multipartForm struct {
+	values url.Values
+	files  map[string][]*UploadedFile
+}
+
+// readMultipart reads multipart/form-data body keeping up to MaxMultipartMemory bytes
+// of files in memory and the rest in temporary files, removed once the handler returns.
+// Files larger than limits of their fields are rejected as soon as they exceed them.
+func (r nativeRequest) readMultipart(limits map[string]int64) (*multipartForm, error) {
+	reader, err := r.request.MultipartReader()
+	if err != nil {
+		return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+	}
+
+	form := &multipartForm{values: url.Values{}, files: map[string][]*UploadedFile{}}
+	memory, valuesSize := r.options.MaxMultipartMemory, int64(maxMultipartValues)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+		}
+
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+
+		if part.FileName() == "" {
+			var value bytes.Buffer
+			n, err := io.CopyN(&value, part, valuesSize+1)
+			if err != nil && err != io.EOF {
+				return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+			}
+			if valuesSize -= n; valuesSize < 0 {
+				return nil, response.NewProblem(http.StatusBadRequest).WithDetail(multipart.ErrMessageTooLarge.Error())
+			}
+			form.values.Add(name, value.String())
+			continue
+		}
+
+		limit, limited := limits[name]
+		f, err := r.readFile(part, limit, limited, &memory)
+		if err != nil {
+			return nil, err
+		}
+		form.files[name] = append(form.files[name], f)
+	}
+}
+
+// readFile reads file part into memory while it fits into the remaining memory, then
+// into a temporary file. Reading stops once the file exceeds limit.
+func (r nativeRequest) readFile(part *multipart.Part, limit int64, limited bool, memory *int64) (*UploadedFile, error) {
+	name := part.FormName()
+	f := &UploadedFile{Filename: part.FileName(), ContentType: part.Header.Get("content-type")}
+
+	var content io.Reader = part
+	if limited {
+		content = io.LimitReader(part, limit+1)
+	}
+
+	var buffer bytes.Buffer
+	n, err := io.CopyN(&buffer, content, *memory+1)
+	if err != nil && err != io.EOF {
+		return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+	}
+
+	if n <= *memory {
+		*memory -= n
+		f.content, f.Size = buffer.Bytes(), n
+	} else {
+		tmp, err := ioutil.TempFile("", "multipart-")
+		if err != nil {
+			return nil, err
+		}
+		r.onClose(func() { os.Remove(tmp.Name()) })
+
+		written, err := io.Copy(tmp, io.MultiReader(&buffer, content))
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+		}
+		f.path, f.Size = tmp.Name(), written
+	}
+
+	if limited && f.Size > limit {
+		message := fmt.Sprintf("file '%s' must not be larger than %d bytes", name, limit)
+		p := response.NewProblem(http.StatusBadRequest).WithDetail(message)
+		p.Errors = []response.FieldError{{Field: name, Tag: "file", Message: message}}
+		return nil, p
+	}
+
+	return f, f.detectContentType()
+}
+
+// fileLimits collects `maxsize` tags of file fields of struct pointed by dst, by names
+// of their form fields.
+func fileLimits(t reflect.Type) map[string]int64 {
+	limits := map[string]int64{}
+	t = indirectType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return limits
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup("form")
+		if !tagged && field.Anonymous {
+			for name, limit := range fileLimits(field.Type) {
+				limits[name] = limit
+			}
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Type != uploadedFileType && field.Type != uploadedFileSliceType {
+			continue
+		}
+		if maxSize, err := strconv.ParseInt(field.Tag.Get("maxsize"), 10, 64); err == nil {
+			limits[name] = maxSize
+		}
+	}
+
+	return limits
+}
+
+// bindFiles binds uploaded files to the field, reporting false when the field doesn't
+// hold files at all.
+func bindFiles(v reflect.Value, field reflect.StructField, name string, b binding, fields *[]response.FieldError) bool {
+	if field.Type != uploadedFileType && field.Type != uploadedFileSliceType {
+		return false
+	}
+	if b.files == nil {
+		return true
+	}
+
+	var files []*UploadedFile
+	for _, f := range b.files(name) {
+		if reason, ok := f.accepts(field); !ok {
+			*fields = append(*fields, response.FieldError{Field: name, Tag: "file", Message: fmt.Sprintf("file '%s' %s", name, reason)})
+			return true
+		}
+		files = append(files, f)
+	}
+
+	switch {
+	case len(files) == 0:
+	case field.Type == uploadedFileType:
+		v.Set(reflect.ValueOf(files[0]))
+	default:
+		v.Set(reflect.ValueOf(files))
+	}
+
+	return true
+}
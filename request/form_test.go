@@ -0,0 +1,159 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0Aimage-content")
+
+func Test_Body_URLEncodedForm(t *testing.T) {
+	r, _ := http.NewRequest("POST", "/", strings.NewReader("name=lorem&tags=a&tags=b,c&age=31"))
+	r.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	form := profileForm{}
+	err := wrapRequest(r, Options{}.withDefaults()).Body(&form)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "lorem", form.Name)
+	assert.Equal(t, 31, form.Age)
+	assert.Equal(t, []string{"a", "b", "c"}, form.Tags)
+	assert.Nil(t, form.Avatar)
+}
+
+func Test_Body_URLEncodedForm_Validation(t *testing.T) {
+	r, _ := http.NewRequest("POST", "/", strings.NewReader("age=31"))
+	r.Header.Set("content-type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	err := wrapRequest(r, Options{}.withDefaults()).Body(&profileForm{})
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.StatusCode())
+	assert.Equal(t, "name", problem.Errors[0].Field)
+}
+
+func Test_Body_MultipartForm(t *testing.T) {
+	r := multipartRequest(t, map[string]string{"name": "lorem"}, map[string][]byte{"avatar": pngHeader, "attachments": []byte("plain text")})
+
+	form := profileForm{}
+	err := wrapRequest(r, Options{}.withDefaults()).Body(&form)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "lorem", form.Name)
+	assert.Equal(t, "avatar.bin", form.Avatar.Filename)
+	assert.Equal(t, "image/png", form.Avatar.ContentType)
+	assert.Equal(t, int64(len(pngHeader)), form.Avatar.Size)
+	assert.Len(t, form.Attachments, 1)
+
+	var b bytes.Buffer
+	_, err = form.Attachments[0].WriteTo(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain text", b.String())
+
+	path, err := form.Avatar.SaveTemp("", "avatar-*")
+	assert.NoError(t, err)
+	defer os.Remove(path)
+
+	saved, _ := ioutil.ReadFile(path)
+	assert.Equal(t, pngHeader, saved)
+}
+
+func Test_Body_MultipartForm_FileLimits(t *testing.T) {
+	r := multipartRequest(t, map[string]string{"name": "lorem"}, map[string][]byte{"avatar": []byte("not an image")})
+
+	err := wrapRequest(r, Options{}.withDefaults()).Body(&profileForm{})
+
+	problem, ok := err.(response.Problem)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, problem.StatusCode())
+	assert.Equal(t, "file 'avatar' must be one of image/png,image/gif", problem.Detail)
+
+	r = multipartRequest(t, map[string]string{"name": "lorem"}, map[string][]byte{"avatar": append(pngHeader, make([]byte, 64)...)})
+
+	err = wrapRequest(r, Options{}.withDefaults()).Body(&profileForm{})
+
+	assert.Equal(t, "file 'avatar' must not be larger than 64 bytes", err.Error())
+}
+
+func Test_Body_MultipartForm_TempFilesRemoved(t *testing.T) {
+	var path string
+	handler := HandleAction(func(r Request) response.Response {
+		form := profileForm{}
+		if err := r.Body(&form); err != nil {
+			return response.BadRequest(err)
+		}
+
+		path = form.Attachments[0].path
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+		return response.Ok(form.Attachments[0].Size)
+	}, Options{MaxMultipartMemory: 4})
+
+	r := multipartRequest(t, map[string]string{"name": "lorem"}, map[string][]byte{"attachments": []byte("larger than memory")})
+	response := httpResponse(handler, r)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "{\"data\":18}", response.Body.String())
+	assert.NotEmpty(t, path)
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_Body_MultipartForm_MaxSizeWhileReading(t *testing.T) {
+	r := multipartRequest(t, map[string]string{"name": "lorem"}, map[string][]byte{"avatar": append(pngHeader, make([]byte, 1<<20)...)})
+	body := &countingReader{Reader: r.Body}
+	r.Body = ioutil.NopCloser(body)
+	r.ContentLength = -1
+
+	err := wrapRequest(r, Options{}.withDefaults()).Body(&profileForm{})
+
+	assert.Equal(t, "file 'avatar' must not be larger than 64 bytes", err.Error())
+	assert.Less(t, body.read, 64<<10)
+}
+
+type countingReader struct {
+	io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.read += n
+	return n, err
+}
+
+type profileForm struct {
+	Name        string          `form:"name" validate:"required"`
+	Age         int             `form:"age"`
+	Tags        []string        `form:"tags"`
+	Avatar      *UploadedFile   `form:"avatar" maxsize:"64" accept:"image/png,image/gif"`
+	Attachments []*UploadedFile `form:"attachments"`
+}
+
+func multipartRequest(t *testing.T, values map[string]string, files map[string][]byte) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range values {
+		assert.NoError(t, w.WriteField(name, value))
+	}
+	for name, content := range files {
+		part, err := w.CreateFormFile(name, name+".bin")
+		assert.NoError(t, err)
+		part.Write(content)
+	}
+	assert.NoError(t, w.Close())
+
+	r, _ := http.NewRequest("POST", "/", &body)
+	r.Header.Set("content-type", w.FormDataContentType())
+	return r
+}
@@ -0,0 +1,289 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// patchOperation single operation of JSON Patch document (RFC 6902, section 4).
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonPatch applies operations to doc in order. Malformed operations are reported as
+// Bad Request, operations which cannot be applied as Unprocessable Entity and failed
+// tests as Conflict.
+func jsonPatch(doc interface{}, operations []patchOperation) (interface{}, error) {
+	for i, operation := range operations {
+		var err error
+		if doc, err = operation.apply(doc); err != nil {
+			var problem response.Problem
+			if !errors.As(err, &problem) {
+				return nil, err
+			}
+			return nil, problem.WithDetail(fmt.Sprintf("patch operation %d (%s '%s') %s", i, operation.Op, operation.Path, problem.Detail))
+		}
+	}
+
+	return doc, nil
+}
+
+func (o patchOperation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, malformedPatch(err.Error())
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		value, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == "add" {
+			return addValue(doc, path, value)
+		}
+		if o.Op == "replace" {
+			if doc, err = removeValue(doc, path); err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, value)
+		}
+
+		current, err := getValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(normalizeJSON(current), normalizeJSON(value)) {
+			return nil, response.NewProblem(http.StatusConflict).WithDetail("tested value differs")
+		}
+		return doc, nil
+	case "remove":
+		return removeValue(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, malformedPatch(err.Error())
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == "copy" {
+			return addValue(doc, path, copyJSON(value))
+		}
+		if strings.HasPrefix(o.Path+"/", o.From+"/") && o.Path != o.From {
+			return nil, unprocessablePatch("cannot move value into itself")
+		}
+		if doc, err = removeValue(doc, from); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	default:
+		return nil, malformedPatch("has unknown operation")
+	}
+}
+
+func (o patchOperation) value() (interface{}, error) {
+	if len(o.Value) == 0 {
+		return nil, malformedPatch("is missing value")
+	}
+
+	value, err := decodeJSON(o.Value)
+	if err != nil {
+		return nil, malformedPatch(err.Error())
+	}
+
+	return value, nil
+}
+
+func malformedPatch(reason string) response.Problem {
+	return response.NewProblem(http.StatusBadRequest).WithDetail(reason)
+}
+
+func unprocessablePatch(reason string) response.Problem {
+	return response.NewProblem(http.StatusUnprocessableEntity).WithDetail(reason)
+}
+
+// parsePointer splits JSON Pointer into reference tokens (RFC 6901).
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("has malformed pointer '%s'", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, unprocessablePatch("points to a missing value")
+			}
+			doc = value
+		case []interface{}:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, unprocessablePatch("points to a missing value")
+		}
+	}
+
+	return doc, nil
+}
+
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			i, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			inserted := make([]interface{}, 0, len(node)+1)
+			inserted = append(append(append(inserted, node[:i]...), value), node[i:]...)
+			return inserted, nil
+		default:
+			return nil, unprocessablePatch("points to a missing value")
+		}
+	}, value)
+}
+
+func removeValue(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, unprocessablePatch("points to a missing value")
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			// spliced into a new slice, so the original array of the caller stays intact
+			spliced := make([]interface{}, 0, len(node)-1)
+			return append(append(spliced, node[:i]...), node[i+1:]...), nil
+		default:
+			return nil, unprocessablePatch("points to a missing value")
+		}
+	}, nil)
+}
+
+// updateParent replaces container holding the last token of path with result of update,
+// replacing the whole document when path is empty.
+func updateParent(doc interface{}, path []string, update func(parent interface{}, token string) (interface{}, error), root interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return root, nil
+	}
+	if len(path) == 1 {
+		return update(doc, path[0])
+	}
+
+	child, err := getValue(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateParent(child, path[1:], update, root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		node[path[0]] = child
+	case []interface{}:
+		i, _ := arrayIndex(path[0], len(node)-1)
+		node[i] = child
+	}
+
+	return doc, nil
+}
+
+// arrayIndex parses token referencing element of array, not greater than max.
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, unprocessablePatch(fmt.Sprintf("points to a missing array index '%s'", token))
+	}
+
+	return i, nil
+}
+
+// normalizeJSON converts numbers into float64, so equal numbers compare equal regardless
+// of their representation, e.g. 1 and 1.0.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for name, value := range v {
+			normalized[name] = normalizeJSON(value)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, value := range v {
+			normalized[i] = normalizeJSON(value)
+		}
+		return normalized
+	default:
+		return v
+	}
+}
+
+func copyJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		copied := map[string]interface{}{}
+		for name, value := range v {
+			copied[name] = copyJSON(value)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, value := range v {
+			copied[i] = copyJSON(value)
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,132 @@
+package request
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func jsonPatchRequest(body string) *http.Request {
+	request, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+	request.Header.Set("content-type", JSONPatchMediaType)
+	return request
+}
+
+func Test_JSONPatch(t *testing.T) {
+	article, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.JSONPatch(a) })
+
+	response := httpResponse(handler, jsonPatchRequest(`[
+		{"op":"test","path":"/views","value":3},
+		{"op":"replace","path":"/title","value":"ipsum"},
+		{"op":"add","path":"/tags/-","value":"c"},
+		{"op":"remove","path":"/tags/0"},
+		{"op":"remove","path":"/author/email"}
+	]`))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "ipsum", article.Title)
+	assert.Equal(t, []string{"b", "c"}, article.Tags)
+	assert.Equal(t, "John", article.Author.Name)
+	assert.Equal(t, "", article.Author.Email)
+}
+
+func Test_JSONPatch_Errors(t *testing.T) {
+	_, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.JSONPatch(a) })
+
+	tests := []struct {
+		patch  string
+		status int
+		detail string
+	}{
+		{`{"op":"add"}`, http.StatusBadRequest, ""},
+		{`[{"op":"increment","path":"/views"}]`, http.StatusBadRequest, "unknown operation"},
+		{`[{"op":"add","path":"views","value":1}]`, http.StatusBadRequest, "malformed pointer"},
+		{`[{"op":"add","path":"/views"}]`, http.StatusBadRequest, "missing value"},
+		{`[{"op":"remove","path":"/missing"}]`, http.StatusUnprocessableEntity, "patch operation 0 (remove '/missing') points to a missing value"},
+		{`[{"op":"add","path":"/tags/5","value":"c"}]`, http.StatusUnprocessableEntity, "missing array index '5'"},
+		{`[{"op":"move","from":"/author","path":"/author/name"}]`, http.StatusUnprocessableEntity, "cannot move value into itself"},
+		{`[{"op":"test","path":"/views","value":4}]`, http.StatusConflict, "tested value differs"},
+		{`[{"op":"remove","path":"/title"}]`, http.StatusUnprocessableEntity, `"field":"title"`},
+	}
+
+	for _, test := range tests {
+		response := httpResponse(handler, jsonPatchRequest(test.patch))
+
+		assert.Equal(t, test.status, response.Code, test.patch)
+		assert.Contains(t, response.Body.String(), test.detail, test.patch)
+	}
+}
+
+func Test_JSONPatch_UnsupportedMediaType(t *testing.T) {
+	_, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.JSONPatch(a) })
+
+	response := httpResponse(handler, mergePatchRequest(`[]`))
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.Code)
+	assert.Contains(t, response.Body.String(), JSONPatchMediaType)
+}
+
+func Test_jsonPatch(t *testing.T) {
+	tests := []struct {
+		doc    string
+		patch  string
+		result string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"foo":{"bar":[1]}}`, `[{"op":"copy","from":"/foo/bar","path":"/baz"},{"op":"add","path":"/baz/-","value":2}]`, `{"foo":{"bar":[1]},"baz":[1,2]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, `{"~1":10}`},
+		{`{"baz":[1.0]}`, `[{"op":"test","path":"/baz","value":[1]}]`, `{"baz":[1.0]}`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+
+	for _, test := range tests {
+		doc, _ := decodeJSON([]byte(test.doc))
+		expected, _ := decodeJSON([]byte(test.result))
+		var operations []patchOperation
+		json.Unmarshal([]byte(test.patch), &operations)
+
+		patched, err := jsonPatch(doc, operations)
+
+		assert.Nil(t, err, test.patch)
+		assert.Equal(t, expected, patched, test.patch)
+	}
+}
+
+func Test_JSONPatch_Atomic(t *testing.T) {
+	article := patchedArticle{Title: "lorem", Views: 3}
+	handler := HandleAction(func(r Request) response.Response {
+		if err := r.JSONPatch(&article); err != nil {
+			return response.BadRequest(err)
+		}
+		return response.Ok(article)
+	})
+	response := httpResponse(handler, jsonPatchRequest(`[{"op":"replace","path":"/title","value":"ipsum"},{"op":"remove","path":"/missing"}]`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Equal(t, "lorem", article.Title)
+}
+
+func Test_jsonPatch_KeepsSlices(t *testing.T) {
+	tags := []interface{}{"a", "b", "c"}
+	doc := map[string]interface{}{"tags": tags}
+	var operations []patchOperation
+	json.Unmarshal([]byte(`[{"op":"remove","path":"/tags/0"},{"op":"add","path":"/tags/1","value":"d"}]`), &operations)
+
+	patched, err := jsonPatch(doc, operations)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"b", "d", "c"}, patched.(map[string]interface{})["tags"])
+	assert.Equal(t, []interface{}{"a", "b", "c"}, tags)
+}
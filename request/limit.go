@@ -0,0 +1,106 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// BodyOptions controls how strictly bodies of requests are decoded.
+type BodyOptions struct {
+	// MaxSize bytes of body accepted, larger bodies are rejected with 413 Request Entity
+	// Too Large. Unlimited when 0.
+	MaxSize int64
+
+	// DisallowUnknownFields rejects JSON objects with keys not matching any field.
+	DisallowUnknownFields bool
+
+	// DisallowTrailingData rejects bodies with anything but whitespace after the JSON document.
+	DisallowTrailingData bool
+
+	// UseNumber decodes JSON numbers into interface{} as json.Number instead of float64.
+	UseNumber bool
+}
+
+func (o BodyOptions) decodeOptions() codec.DecodeOptions {
+	return codec.DecodeOptions{
+		DisallowUnknownFields: o.DisallowUnknownFields,
+		DisallowTrailingData:  o.DisallowTrailingData,
+		UseNumber:             o.UseNumber,
+	}
+}
+
+var errBodyTooLarge = errors.New("request body too large")
+
+type bodyOptionsKey struct{}
+
+// WithBodyOptions overrides body options of the router for routes it's used with, e.g.
+// router.With(request.WithBodyOptions(request.BodyOptions{MaxSize: 1 << 20})).Post(...)
+func WithBodyOptions(options BodyOptions) ContextHandler {
+	return func(r Request) (context.Context, response.Response) {
+		return context.WithValue(r.Context(), bodyOptionsKey{}, options), nil
+	}
+}
+
+// bodyOptions returns options of the route, falling back to options of the router.
+func (r nativeRequest) bodyOptions() BodyOptions {
+	if options, ok := r.Context().Value(bodyOptionsKey{}).(BodyOptions); ok {
+		return options
+	}
+
+	return r.options.Body
+}
+
+// limitBody makes sure body of request is not larger than allowed. Reading from the
+// returned body fails once the limit is exceeded, which is reported by exceeded().
+func (r nativeRequest) limitBody(maxSize int64) (*limitedReader, error) {
+	if r.request.ContentLength > maxSize && maxSize > 0 {
+		return nil, entityTooLarge(maxSize)
+	}
+
+	limited := &limitedReader{ReadCloser: r.request.Body, remaining: maxSize, unlimited: maxSize <= 0}
+	r.request.Body = limited
+
+	return limited, nil
+}
+
+func entityTooLarge(maxSize int64) response.Problem {
+	return response.NewProblem(http.StatusRequestEntityTooLarge).
+		WithDetail(fmt.Sprintf("request body must not be larger than %d bytes", maxSize))
+}
+
+type limitedReader struct {
+	io.ReadCloser
+	remaining int64
+	unlimited bool
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.unlimited {
+		return l.ReadCloser.Read(p)
+	}
+	if l.remaining < 0 {
+		l.exceeded = true
+		return 0, errBodyTooLarge
+	}
+
+	// read one byte more than allowed to tell apart body of exactly allowed size
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		l.exceeded = true
+		return n + int(l.remaining), errBodyTooLarge
+	}
+
+	return n, err
+}
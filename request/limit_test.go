@@ -0,0 +1,109 @@
+package request
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_Body_MaxSize(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{MaxSize: 16}})
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem-ipsum-dolor\"}"))
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+	assert.Contains(t, response.Body.String(), "request body must not be larger than 16 bytes")
+}
+
+func Test_Body_MaxSize_UnknownLength(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{MaxSize: 16}})
+
+	request, _ := http.NewRequest("POST", "/", ioutil.NopCloser(strings.NewReader("{\"a\":\"lorem-ipsum-dolor\"}")))
+	assert.Equal(t, int64(0), request.ContentLength)
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+}
+
+func Test_Body_MaxSize_Exact(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{MaxSize: 16}})
+
+	request, _ := http.NewRequest("POST", "/", ioutil.NopCloser(strings.NewReader("{\"a\":\"lorem-ip\"}")))
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func Test_Body_MaxSize_Form(t *testing.T) {
+	handler := HandleAction(func(r Request) response.Response {
+		form := profileForm{}
+		if err := r.Body(&form); err != nil {
+			return response.BadRequest(err)
+		}
+		return response.Ok(form.Name)
+	}, Options{Body: BodyOptions{MaxSize: 8}})
+
+	request, _ := http.NewRequest("POST", "/", ioutil.NopCloser(strings.NewReader("name=lorem-ipsum")))
+	request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+}
+
+func Test_Body_DisallowUnknownFields(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{DisallowUnknownFields: true}})
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem\",\"b\":1}"))
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "unknown field")
+}
+
+func Test_Body_DisallowTrailingData(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{DisallowTrailingData: true}})
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem\"} {\"a\":\"ipsum\"}"))
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "body must contain a single document")
+
+	request, _ = http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem\"}\n"))
+	response = httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func Test_Body_UseNumber(t *testing.T) {
+	var decoded map[string]interface{}
+	handler := HandleAction(func(r Request) response.Response {
+		if err := r.Body(&decoded); err != nil {
+			return response.BadRequest(err)
+		}
+		return response.NoContent()
+	}, Options{Body: BodyOptions{UseNumber: true}})
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"id\":9007199254740993}"))
+	httpResponse(handler, request)
+
+	assert.Equal(t, json.Number("9007199254740993"), decoded["id"])
+}
+
+func Test_WithBodyOptions(t *testing.T) {
+	handler := HandleAction(bodyToResponse, Options{Body: BodyOptions{MaxSize: 1024}})
+	handlerToTest := HandleContext(WithBodyOptions(BodyOptions{MaxSize: 4}))(handler)
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem\"}"))
+	response := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+}
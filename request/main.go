@@ -2,18 +2,32 @@ package request
 
 import (
 	"context"
-	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
+	"mime"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-playground/validator"
+	"gitlab.com/devmint/go-restful/codec"
 	"gitlab.com/devmint/go-restful/response"
 )
 
-var validate RequestBodyValidation
+// defaultValidator is used by handlers created without their own validator.
+var defaultValidator RequestBodyValidation = validator.New()
+
+// RegisterValidator replaces validator of handlers created afterwards without their own
+// validator.
+//
+// Deprecated: validator shared by the whole process affects every router, set
+// Options.Validator (or RouterOptions of restful router) instead.
+func RegisterValidator(v RequestBodyValidation) {
+	defaultValidator = v
+}
 
 // RestfulHandler replacement for http.HandlerFunc
 type RestfulHandler func(Request) response.Response
@@ -25,8 +39,58 @@ type ContextHandler func(Request) (context.Context, response.Response)
 // Request replacement for *http.Request with easy access to most important variables or parameters.
 type Request interface {
 	Param(key string) string
+
+	// Typed accessors of path parameters. Returned errors are Bad Request responses
+	// describing malformed parameter, ready to be returned by handler.
+	ParamInt(key string, constraints ...Constraint) (int, error)
+	ParamInt64(key string, constraints ...Constraint) (int64, error)
+	ParamBool(key string) (bool, error)
+	ParamUUID(key string) (string, error)
+	ParamTime(key string, constraints ...Constraint) (time.Time, error)
+
 	Query(key string, onMissing ...string) string
+
+	// BindQuery fills struct pointed by dst with query parameters named by `query`
+	// tags and validates it.
+	BindQuery(dst interface{}) error
+
+	// BindHeaders fills struct pointed by dst with headers named by `header` tags and
+	// validates it.
+	BindHeaders(dst interface{}) error
+
+	// BindCookies fills struct pointed by dst with cookies named by `cookie` tags and
+	// validates it.
+	BindCookies(dst interface{}) error
+
+	// IfMatch returns entity tags from If-Match header, ["*"] when any is accepted.
+	IfMatch() []string
+
+	// IfUnmodifiedSince returns time from If-Unmodified-Since header, false when it's
+	// missing or malformed.
+	IfUnmodifiedSince() (time.Time, bool)
+
+	// Precondition checks If-Match and If-Unmodified-Since headers against the current
+	// entity tag and modification time of the resource. Returned error is Precondition
+	// Failed problem, ready to be returned by handler. See RequirePrecondition.
+	Precondition(etag string, lastModified time.Time) error
+
+	// Body decodes body of request into typeOfBody and validates it. JSON, XML and other
+	// registered codecs are supported, as well as url-encoded and multipart forms bound
+	// by `form` tags (see UploadedFile).
 	Body(typeOfBody interface{}) error
+
+	// MergePatch applies JSON Merge Patch (RFC 7396) sent as application/merge-patch+json
+	// to value pointed by existing and validates the result.
+	MergePatch(existing interface{}) error
+
+	// JSONPatch applies JSON Patch (RFC 6902) sent as application/json-patch+json to
+	// value pointed by existing and validates the result.
+	JSONPatch(existing interface{}) error
+
+	// Error describes err returned by service layer as a response, using error map of
+	// the router (see Options.Errors and response.ErrorMap).
+	Error(err error) response.Response
+
 	Context() context.Context
 	Request() *http.Request
 }
@@ -35,44 +99,111 @@ type RequestBodyValidation interface {
 	Struct(s interface{}) error
 }
 
+// Options configures how requests are decoded by a single router, so routers living
+// in the same process don't share their settings.
+type Options struct {
+	// Validator checks structs decoded from request, go-playground validator by default.
+	Validator RequestBodyValidation
+
+	// MaxMultipartMemory bytes of multipart/form-data body kept in memory, the rest of
+	// files is stored in temporary files. 32 MB by default.
+	MaxMultipartMemory int64
+
+	// Body size limit and strictness of decoding, can be overridden per route with
+	// WithBodyOptions.
+	Body BodyOptions
+
+	// Logger receives panics recovered from handlers, standard error by default.
+	Logger Logger
+
+	// Debug exposes messages and stack traces of recovered panics to clients. It should
+	// be enabled in development only.
+	Debug bool
+
+	// ETag computes entity tags of successful GET and HEAD responses from their bodies.
+	// Disabled by default, handlers can set their own with res.Header().WithETag.
+	// It doesn't check If-Match of updates, handlers do it with Request.Precondition.
+	ETag ETagMode
+
+	// Errors maps errors of service layer to problems returned by Request.Error,
+	// response.DefaultErrorMap by default. Unless mapped, errors get Internal Server
+	// Error with redacted detail.
+	Errors *response.ErrorMap
+}
+
+func (o Options) withDefaults() Options {
+	if o.Validator == nil {
+		o.Validator = defaultValidator
+	}
+	if o.Logger == nil {
+		o.Logger = defaultLogger
+	}
+	if o.Errors == nil {
+		o.Errors = response.DefaultErrorMap
+	}
+	if o.MaxMultipartMemory <= 0 {
+		o.MaxMultipartMemory = defaultMaxMultipartMemory
+	}
+
+	return o
+}
+
+func firstOptions(options []Options) Options {
+	if len(options) > 0 {
+		return options[0].withDefaults()
+	}
+
+	return Options{}.withDefaults()
+}
+
 const (
-	appJSON = "application/json"
-	appXML  = "application/xml"
+	appJSON = codec.JSONMediaType
+	appXML  = codec.XMLMediaType
 )
 
+var errNotAcceptable = errors.New("none of the media types from the Accept header can be produced")
+
 // HandleAction replacement for http.HandlerFunc
-func HandleAction(cb func(req Request) response.Response) http.HandlerFunc {
+func HandleAction(cb func(req Request) response.Response, options ...Options) http.HandlerFunc {
+	opts := firstOptions(options)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := cb(wrapRequest(r))
-		for name, value := range response.Header() {
-			w.Header().Set(name, value)
+		mediaType, ok := negotiateResponse(r)
+		if !ok {
+			render(w, appJSON, response.NotAcceptable(errNotAcceptable))
+			return
 		}
 
-		switch r.Header.Get("content-type") {
-		case appJSON:
-			renderJSON(w, response)
-		case appXML:
-			renderXML(w, response)
-		default:
-			renderJSON(w, response)
+		req := wrapRequest(r, opts)
+		defer req.close()
+
+		res := callAction(cb, req)
+		body, res, mediaType := encode(res, mediaType)
+		if opts.notModified(r, res, body) {
+			writeNotModified(w, res)
+			return
 		}
+
+		write(w, mediaType, res, body)
 	})
 }
 
 // HandleContext replacement for func(http.Handler) http.Handler
-func HandleContext(cb func(req Request) (context.Context, response.Response)) func(http.Handler) http.Handler {
+func HandleContext(cb func(req Request) (context.Context, response.Response), options ...Options) func(http.Handler) http.Handler {
+	opts := firstOptions(options)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, res := cb(wrapRequest(r))
+			mediaType, ok := negotiateResponse(r)
+			if !ok {
+				render(w, appJSON, response.NotAcceptable(errNotAcceptable))
+				return
+			}
+
+			req := wrapRequest(r, opts)
+			defer req.close()
+
+			ctx, res := callContext(cb, req)
 			if res != nil {
-				switch r.Header.Get("content-type") {
-				case appJSON:
-					renderJSON(w, res)
-				case appXML:
-					renderXML(w, res)
-				default:
-					renderJSON(w, res)
-				}
+				render(w, mediaType, res)
 				return
 			}
 
@@ -82,14 +213,30 @@ func HandleContext(cb func(req Request) (context.Context, response.Response)) fu
 }
 
 type nativeRequest struct {
-	request   *http.Request
-	validator RequestBodyValidation
+	request *http.Request
+	options Options
+
+	// closers release resources held by the request, e.g. temporary files of uploads.
+	closers *[]func()
+}
+
+// onClose registers fn to run once the handler returns.
+func (r nativeRequest) onClose(fn func()) {
+	*r.closers = append(*r.closers, fn)
+}
+
+func (r nativeRequest) close() {
+	for _, fn := range *r.closers {
+		fn()
+	}
 }
 
 func (r nativeRequest) Request() *http.Request { return r.request }
 
 func (r nativeRequest) Context() context.Context { return r.request.Context() }
 
+func (r nativeRequest) Error(err error) response.Response { return r.options.Errors.FromError(err) }
+
 func (r nativeRequest) Param(key string) string { return chi.URLParam(r.request, key) }
 
 func (r nativeRequest) Query(key string, onMissing ...string) string {
@@ -105,56 +252,119 @@ func (r nativeRequest) Query(key string, onMissing ...string) string {
 }
 
 func (r nativeRequest) Body(typeOfBody interface{}) error {
-	body := r.request.Body
-	if body == nil {
+	if r.request.Body == nil {
 		return errors.New("empty body from request")
 	}
 
-	contentType := appJSON
-	if r.request.Header.Get("content-type") != "" {
-		contentType = r.request.Header.Get("content-type")
+	mediaType, params := appJSON, map[string]string{}
+	if contentType := r.request.Header.Get("content-type"); contentType != "" {
+		var err error
+		if mediaType, params, err = mime.ParseMediaType(contentType); err != nil {
+			return unsupportedMediaType(fmt.Sprintf("content type '%s' is malformed", contentType))
+		}
+	}
+
+	options := r.bodyOptions()
+	body, err := r.limitBody(options.MaxSize)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case appForm, appMultipart:
+		err := r.bindForm(typeOfBody, mediaType == appMultipart)
+		if body.exceeded {
+			return entityTooLarge(options.MaxSize)
+		}
+		return err
+	}
+
+	c, ok := codec.Lookup(mediaType)
+	if !ok {
+		return unsupportedMediaType(fmt.Sprintf("content type '%s' is unsupported", mediaType))
 	}
 
-	var err error
-	switch contentType {
-	case appJSON:
-		err = json.NewDecoder(body).Decode(&typeOfBody)
-	case appXML:
-		err = xml.NewDecoder(body).Decode(&typeOfBody)
-	default:
-		err = fmt.Errorf("content type '%s' is unsupoorted", r.request.Header.Get("content-type"))
+	reader, err := codec.Transcode(params["charset"], body)
+	if err != nil {
+		return unsupportedMediaType(err.Error())
 	}
 
-	if err == nil {
-		err = r.validator.Struct(typeOfBody)
+	if decoder, ok := c.(codec.OptionsDecoder); ok {
+		err = decoder.DecodeWithOptions(reader, typeOfBody, options.decodeOptions())
+	} else {
+		err = c.Decode(reader, typeOfBody)
+	}
+	if body.exceeded {
+		return entityTooLarge(options.MaxSize)
+	}
+	if err != nil {
+		return err
 	}
 
-	return err
+	if !isStruct(typeOfBody) {
+		return nil
+	}
+
+	tagKey := "json"
+	if strings.HasSuffix(mediaType, "xml") {
+		tagKey = "xml"
+	}
+
+	return validationError(r.options.Validator.Struct(typeOfBody), typeOfBody, tagKey)
 }
 
-func wrapRequest(r *http.Request) nativeRequest {
+func wrapRequest(r *http.Request, options Options) nativeRequest {
 	return nativeRequest{
-		request:   r,
-		validator: validate,
+		request: r,
+		options: options,
+		closers: &[]func(){},
 	}
 }
 
-func init() {
-	validate = validator.New()
+// unsupportedMediaType describes body which cannot be decoded as Unsupported Media Type problem.
+func unsupportedMediaType(detail string) response.Problem {
+	return response.NewProblem(http.StatusUnsupportedMediaType).
+		WithDetail(detail).
+		WithExtension("supported", append(codec.MediaTypes(), appForm, appMultipart))
 }
 
-func RegisterValidator(v RequestBodyValidation) {
-	validate = v
+// isStruct reports whether v is a struct or a pointer to one, the only values struct
+// validators are able to check.
+func isStruct(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t != nil && t.Kind() == reflect.Struct
 }
 
-func renderJSON(w http.ResponseWriter, r response.Response) {
-	w.WriteHeader(r.StatusCode())
-	w.Header().Set("content-type", appJSON)
-	w.Write([]byte(r.GetJSON()))
+func render(w http.ResponseWriter, mediaType string, r response.Response) {
+	body, r, mediaType := encode(r, mediaType)
+	write(w, mediaType, r, body)
 }
 
-func renderXML(w http.ResponseWriter, r response.Response) {
+// encode renders body of response, replacing response which cannot be encoded with
+// Internal Server Error.
+func encode(r response.Response, mediaType string) ([]byte, response.Response, string) {
+	body, err := response.Encode(r, mediaType)
+	if err != nil {
+		r, mediaType = response.InternalServerError(err), appJSON
+		body, _ = response.Encode(r, mediaType)
+	}
+
+	return body, r, mediaType
+}
+
+func write(w http.ResponseWriter, mediaType string, r response.Response, body []byte) {
+	for name, value := range r.Header() {
+		w.Header().Set(name, value)
+	}
+	w.Header().Add("vary", "accept")
+	if len(body) > 0 {
+		w.Header().Set("content-type", response.MediaType(r, mediaType))
+		w.Header().Set("content-length", strconv.Itoa(len(body)))
+	}
 	w.WriteHeader(r.StatusCode())
-	w.Header().Set("content-type", appXML)
-	w.Write([]byte(r.GetXML()))
+	w.Write(body)
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,8 +16,8 @@ import (
 )
 
 const (
-	responseInJSON = "{\"type\":\"http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html\",\"title\":\"Not Found\",\"detail\":\"employee not found\",\"status\":404}"
-	responseInXML  = "<response><type>http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html</type><title>Not Found</title><detail>employee not found</detail><status>404</status></response>"
+	responseInJSON = "{\"type\":\"about:blank\",\"title\":\"Not Found\",\"detail\":\"employee not found\",\"status\":404}"
+	responseInXML  = "<problem xmlns=\"urn:ietf:rfc:7807\"><type>about:blank</type><title>Not Found</title><detail>employee not found</detail><status>404</status></problem>"
 )
 
 var errFoo = errors.New("employee not found")
@@ -29,7 +30,7 @@ func Test_ErrorResponse_JSON(t *testing.T) {
 	response := httpResponse(handler, request)
 
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "application/json", response.Header().Get("content-type"))
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
 	assert.Equal(t, responseInJSON, response.Body.String())
 }
 
@@ -41,7 +42,7 @@ func Test_ErrorResponse_XML(t *testing.T) {
 	response := httpResponse(handler, request)
 
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "application/xml", response.Header().Get("content-type"))
+	assert.Equal(t, "application/problem+xml", response.Header().Get("content-type"))
 	assert.Equal(t, responseInXML, response.Body.String())
 }
 
@@ -83,7 +84,7 @@ func Test_Context_InValid(t *testing.T) {
 	handlerToTest.ServeHTTP(response, request)
 
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "application/json", response.Header().Get("content-type"))
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
 }
 
 func Test_CustomHeaders(t *testing.T) {
@@ -115,7 +116,77 @@ func Test_ParseBody_WithValidation(t *testing.T) {
 	request.Header.Set("content-type", "application/json")
 	response := httpResponse(handler, request)
 
-	assert.Contains(t, response.Body.String(), "Key: 'customBody.A' Error:Field validation for 'A' failed on the 'iscolor' tag")
+	assert.Contains(t, response.Body.String(), "\"detail\":\"request body failed validation\"")
+	assert.Contains(t, response.Body.String(), "{\"field\":\"a\",\"tag\":\"iscolor\",\"message\":\"a failed on the 'iscolor' rule\"}")
+	assert.NotContains(t, response.Body.String(), "customBody")
+}
+
+func Test_ParseBody_CustomValidator(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(bodyToResponse, Options{Validator: rejectingValidator{}}))
+	body, _ := json.Marshal(map[string]string{"a": "lorem-ipsum"})
+
+	request, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+	request.Header.Set("content-type", "application/json")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "rejected")
+}
+
+func Test_RegisterValidator(t *testing.T) {
+	previous := defaultValidator
+	RegisterValidator(rejectingValidator{})
+	t.Cleanup(func() { defaultValidator = previous })
+
+	handler := http.HandlerFunc(HandleAction(bodyToResponse))
+	body, _ := json.Marshal(map[string]string{"a": "lorem-ipsum"})
+
+	request, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+	request.Header.Set("content-type", "application/json")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "rejected")
+}
+
+func Test_ParseBody_ContentTypeParameters(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(bodyToResponse))
+
+	for _, contentType := range []string{"application/json; charset=utf-8", "application/vnd.api+json", "Application/JSON"} {
+		request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":\"lorem-ipsum\"}"))
+		request.Header.Set("content-type", contentType)
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, "{\"data\":{\"a\":\"lorem-ipsum\"}}", response.Body.String(), contentType)
+	}
+}
+
+func Test_ParseBody_Latin1XML(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(bodyToResponse))
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("<body><a>caf\xe9</a></body>"))
+	request.Header.Set("content-type", "text/xml; charset=iso-8859-1")
+	request.Header.Set("accept", "application/json")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, "{\"data\":{\"a\":\"café\"}}", response.Body.String())
+}
+
+func Test_ParseBody_UnsupportedMediaType(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(bodyToResponse))
+
+	for contentType, detail := range map[string]string{
+		"text/csv":                         "content type 'text/csv' is unsupported",
+		"application/json; charset=koi8-r": "charset 'koi8-r' is unsupported",
+		"application/json; charset":        "content type 'application/json; charset' is malformed",
+	} {
+		request, _ := http.NewRequest("POST", "/", strings.NewReader("a,b"))
+		request.Header.Set("content-type", contentType)
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, response.Code, contentType)
+		assert.Contains(t, response.Body.String(), detail, contentType)
+	}
 }
 
 func Test_Redirect(t *testing.T) {
@@ -130,6 +201,18 @@ func Test_Redirect(t *testing.T) {
 	assert.Equal(t, "http://www.onet.pl", response.Header().Get("Location"))
 }
 
+func Test_NoContent(t *testing.T) {
+	handler := HandleAction(func(r Request) response.Response { return response.NoContent() })
+
+	request, _ := http.NewRequest("DELETE", "/", nil)
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Empty(t, response.Body.String())
+	assert.Empty(t, response.Header().Get("content-type"))
+	assert.Empty(t, response.Header().Get("content-length"))
+}
+
 func collectionHandler(r Request) response.Response {
 	ctx := r.Context()
 	if ctx.Value("valid") == nil {
@@ -189,6 +272,10 @@ func httpResponse(handler http.HandlerFunc, req *http.Request) *httptest.Respons
 	return w
 }
 
+type rejectingValidator struct{}
+
+func (rejectingValidator) Struct(interface{}) error { return errors.New("rejected") }
+
 type customBody struct {
 	A string `json:"a" xml:"a" validate:"iscolor"`
 }
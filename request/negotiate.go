@@ -0,0 +1,165 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// mediaRange is a single element of an Accept header, e.g. "application/*;q=0.8".
+type mediaRange struct {
+	mainType string
+	subType  string
+	params   int
+	quality  float64
+}
+
+// specificity orders media ranges the way RFC 7231 section 5.3.2 does: a concrete
+// type overrides a subtype wildcard, which in turn overrides "*/*".
+func (m mediaRange) specificity() int {
+	switch {
+	case m.mainType == "*":
+		return 0
+	case m.subType == "*":
+		return 1
+	default:
+		return 2 + m.params
+	}
+}
+
+func (m mediaRange) matches(mediaType string) bool {
+	mainType, subType := splitMediaType(mediaType)
+	if m.mainType != "*" && m.mainType != mainType {
+		return false
+	}
+
+	return m.subType == "*" || m.subType == subType
+}
+
+// parseAccept parses the value of an Accept header. Invalid elements are skipped.
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, element := range strings.Split(header, ",") {
+		parts := strings.Split(element, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+		if mediaType == "" {
+			continue
+		}
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+
+		mainType, subType := splitMediaType(mediaType)
+		if mainType == "" || subType == "" || (mainType == "*" && subType != "*") {
+			continue
+		}
+
+		current := mediaRange{mainType: mainType, subType: subType, quality: 1}
+		valid := true
+		for _, param := range parts[1:] {
+			name, value := splitParam(param)
+			if name != "q" {
+				current.params++
+				continue
+			}
+
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			current.quality = q
+		}
+
+		if valid {
+			ranges = append(ranges, current)
+		}
+	}
+
+	return ranges
+}
+
+// quality returns the weight the client assigned to mediaType, taken from the most
+// specific range matching it. Zero means the media type is not acceptable.
+func quality(ranges []mediaRange, mediaType string) float64 {
+	best, q := -1, 0.0
+	for _, r := range ranges {
+		if !r.matches(mediaType) {
+			continue
+		}
+		if s := r.specificity(); s > best {
+			best, q = s, r.quality
+		}
+	}
+
+	return q
+}
+
+// Negotiate selects one of the offered media types for the response to r. When the
+// client sends an Accept header the offer with the highest quality wins, ties being
+// broken by the request Content-Type and then by the order of offers. Clients without
+// an Accept header keep the original behaviour of getting their Content-Type back.
+// The second value is false when none of the offers is acceptable.
+func Negotiate(r *http.Request, offers []string) (string, bool) {
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(r.Header.Get("content-type"), ";")[0]))
+
+	accept := strings.TrimSpace(strings.Join(r.Header.Values("accept"), ","))
+	if accept == "" {
+		for _, offer := range offers {
+			if offer == contentType {
+				return offer, true
+			}
+		}
+		return offers[0], true
+	}
+
+	ranges := parseAccept(accept)
+	selected, selectedQuality := "", 0.0
+	for _, offer := range offers {
+		q := quality(ranges, offer)
+		if q > selectedQuality || (q == selectedQuality && q > 0 && offer == contentType) {
+			selected, selectedQuality = offer, q
+		}
+	}
+
+	return selected, selectedQuality > 0
+}
+
+// problemMediaTypes maps media types of problem details to codecs rendering them, so
+// clients accepting problems only can still be answered.
+var problemMediaTypes = map[string]string{
+	response.ProblemJSON: codec.JSONMediaType,
+	response.ProblemXML:  codec.XMLMediaType,
+}
+
+// negotiateResponse selects codec rendering response to r out of registered ones,
+// problem media types being matched against their base codecs.
+func negotiateResponse(r *http.Request) (string, bool) {
+	mediaType, ok := Negotiate(r, append(codec.MediaTypes(), response.ProblemJSON, response.ProblemXML))
+	if base, isProblem := problemMediaTypes[mediaType]; isProblem {
+		mediaType = base
+	}
+
+	return mediaType, ok
+}
+
+func splitMediaType(mediaType string) (string, string) {
+	slash := strings.Index(mediaType, "/")
+	if slash < 0 {
+		return mediaType, ""
+	}
+
+	return mediaType[:slash], mediaType[slash+1:]
+}
+
+func splitParam(param string) (string, string) {
+	equal := strings.Index(param, "=")
+	if equal < 0 {
+		return strings.ToLower(strings.TrimSpace(param)), ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(param[:equal])), strings.Trim(strings.TrimSpace(param[equal+1:]), "\"")
+}
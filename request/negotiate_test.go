@@ -0,0 +1,117 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_Negotiate_FallbackToContentType(t *testing.T) {
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("content-type", "application/xml")
+
+	mediaType, ok := Negotiate(request, codec.MediaTypes())
+
+	assert.True(t, ok)
+	assert.Equal(t, appXML, mediaType)
+}
+
+func Test_Negotiate_DefaultsToJSON(t *testing.T) {
+	request, _ := http.NewRequest("GET", "/", nil)
+
+	mediaType, ok := Negotiate(request, codec.MediaTypes())
+
+	assert.True(t, ok)
+	assert.Equal(t, appJSON, mediaType)
+}
+
+func Test_Negotiate_Accept(t *testing.T) {
+	cases := map[string]string{
+		"application/xml":                             appXML,
+		"application/json;q=0.5, application/xml":     appXML,
+		"application/*;q=0.2, application/json":       appJSON,
+		"text/html, application/xml;q=0.9, */*;q=0.1": appXML,
+		"*/*":                                 appJSON,
+		"application/*, application/json;q=0": appXML,
+	}
+
+	for accept, expected := range cases {
+		request, _ := http.NewRequest("GET", "/", nil)
+		request.Header.Set("accept", accept)
+
+		mediaType, ok := Negotiate(request, codec.MediaTypes())
+
+		assert.True(t, ok, accept)
+		assert.Equal(t, expected, mediaType, accept)
+	}
+}
+
+func Test_Negotiate_WildcardPrefersContentType(t *testing.T) {
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("accept", "*/*")
+	request.Header.Set("content-type", "application/xml; charset=utf-8")
+
+	mediaType, ok := Negotiate(request, codec.MediaTypes())
+
+	assert.True(t, ok)
+	assert.Equal(t, appXML, mediaType)
+}
+
+func Test_Negotiate_NotAcceptable(t *testing.T) {
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("accept", "text/html, application/json;q=0")
+
+	_, ok := Negotiate(request, codec.MediaTypes())
+
+	assert.False(t, ok)
+}
+
+func Test_HandleAction_AcceptXML(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(notFoundHandler))
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("accept", "application/xml")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "application/problem+xml", response.Header().Get("content-type"))
+	assert.Equal(t, responseInXML, response.Body.String())
+}
+
+func Test_HandleAction_AcceptProblem(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(notFoundHandler))
+
+	tests := map[string]string{
+		"application/problem+xml":                                 "application/problem+xml",
+		"application/problem+json":                                "application/problem+json",
+		"application/problem+json;q=0.5, application/problem+xml": "application/problem+xml",
+	}
+
+	for accept, contentType := range tests {
+		request, _ := http.NewRequest("GET", "/", nil)
+		request.Header.Set("accept", accept)
+		response := httpResponse(handler, request)
+
+		assert.Equal(t, http.StatusNotFound, response.Code, accept)
+		assert.Equal(t, contentType, response.Header().Get("content-type"), accept)
+	}
+}
+
+func Test_HandleAction_NotAcceptable(t *testing.T) {
+	called := false
+	handler := http.HandlerFunc(HandleAction(func(r Request) response.Response {
+		called = true
+		return collectionHandler(r)
+	}))
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("accept", "text/html")
+	response := httpResponse(handler, request)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusNotAcceptable, response.Code)
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
+}
@@ -0,0 +1,203 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// Constraint restricts values accepted by typed accessors of path parameters.
+type Constraint func(*constraints)
+
+type constraints struct {
+	min, max      *int64
+	layout        string
+	after, before *time.Time
+
+	// numeric and temporal name the first constraint of their kind, so constraints
+	// passed to accessor of the other kind are rejected instead of ignored.
+	numeric, temporal string
+}
+
+// Min rejects numeric parameters lower than n.
+func Min(n int64) Constraint { return numeric("Min", func(c *constraints) { c.min = &n }) }
+
+// Max rejects numeric parameters greater than n.
+func Max(n int64) Constraint { return numeric("Max", func(c *constraints) { c.max = &n }) }
+
+// Layout sets format of time parameters, time.RFC3339 by default.
+func Layout(layout string) Constraint {
+	return temporal("Layout", func(c *constraints) { c.layout = layout })
+}
+
+// After rejects time parameters which are not after t.
+func After(t time.Time) Constraint {
+	return temporal("After", func(c *constraints) { c.after = &t })
+}
+
+// Before rejects time parameters which are not before t.
+func Before(t time.Time) Constraint {
+	return temporal("Before", func(c *constraints) { c.before = &t })
+}
+
+func numeric(name string, constraint Constraint) Constraint {
+	return func(c *constraints) {
+		if c.numeric == "" {
+			c.numeric = name
+		}
+		constraint(c)
+	}
+}
+
+func temporal(name string, constraint Constraint) Constraint {
+	return func(c *constraints) {
+		if c.temporal == "" {
+			c.temporal = name
+		}
+		constraint(c)
+	}
+}
+
+func buildConstraints(list []Constraint) constraints {
+	c := constraints{layout: time.RFC3339}
+	for _, constraint := range list {
+		constraint(&c)
+	}
+
+	return c
+}
+
+func (r nativeRequest) ParamInt(key string, constraints ...Constraint) (int, error) {
+	n, err := r.paramInteger(key, strconv.IntSize, constraints)
+	return int(n), err
+}
+
+func (r nativeRequest) ParamInt64(key string, constraints ...Constraint) (int64, error) {
+	return r.paramInteger(key, 64, constraints)
+}
+
+func (r nativeRequest) ParamBool(key string) (bool, error) {
+	value, err := r.requiredParam(key)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, paramError(key, "must be a boolean")
+	}
+
+	return b, nil
+}
+
+func (r nativeRequest) ParamUUID(key string) (string, error) {
+	value, err := r.requiredParam(key)
+	if err != nil {
+		return "", err
+	}
+
+	if !isUUID(value) {
+		return "", paramError(key, "must be an UUID")
+	}
+
+	return strings.ToLower(value), nil
+}
+
+func (r nativeRequest) ParamTime(key string, constraints ...Constraint) (time.Time, error) {
+	value, err := r.requiredParam(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	c := buildConstraints(constraints)
+	if c.numeric != "" {
+		return time.Time{}, constraintError(key, c.numeric)
+	}
+
+	t, err := time.Parse(c.layout, value)
+	if err != nil {
+		return time.Time{}, paramError(key, fmt.Sprintf("must be a time in format '%s'", c.layout))
+	}
+	if c.after != nil && !t.After(*c.after) {
+		return time.Time{}, paramError(key, fmt.Sprintf("must be after %s", c.after.Format(c.layout)))
+	}
+	if c.before != nil && !t.Before(*c.before) {
+		return time.Time{}, paramError(key, fmt.Sprintf("must be before %s", c.before.Format(c.layout)))
+	}
+
+	return t, nil
+}
+
+func (r nativeRequest) paramInteger(key string, bitSize int, constraints []Constraint) (int64, error) {
+	value, err := r.requiredParam(key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, paramError(key, "must be an integer")
+	}
+
+	c := buildConstraints(constraints)
+	if c.temporal != "" {
+		return 0, constraintError(key, c.temporal)
+	}
+	if c.min != nil && n < *c.min {
+		return 0, paramError(key, fmt.Sprintf("must be at least %d", *c.min))
+	}
+	if c.max != nil && n > *c.max {
+		return 0, paramError(key, fmt.Sprintf("must be at most %d", *c.max))
+	}
+
+	return n, nil
+}
+
+func (r nativeRequest) requiredParam(key string) (string, error) {
+	value := r.Param(key)
+	if value == "" {
+		return "", paramError(key, "is missing")
+	}
+
+	return value, nil
+}
+
+// paramError describes malformed path parameter as a Bad Request problem.
+func paramError(key, reason string) response.Problem {
+	return response.NewProblem(http.StatusBadRequest).
+		WithDetail(fmt.Sprintf("path parameter '%s' %s", key, reason)).
+		WithExtension("param", key)
+}
+
+// constraintError reports constraint passed to accessor which cannot apply it. It's
+// a mistake of the handler, not of the client, so it's an Internal Server Error.
+func constraintError(key, constraint string) response.Problem {
+	return response.NewProblem(http.StatusInternalServerError).
+		WithDetail(fmt.Sprintf("constraint %s cannot be applied to path parameter '%s'", constraint, key))
+}
+
+// isUUID checks textual representation of UUID, e.g. "123e4567-e89b-12d3-a456-426614174000".
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9') && !('a' <= c && c <= 'f') && !('A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,96 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_ParamInt(t *testing.T) {
+	req := requestWithParam("id", "12")
+
+	id, err := req.ParamInt("id", Min(1), Max(100))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, id)
+}
+
+func Test_ParamInt_Malformed(t *testing.T) {
+	req := requestWithParam("id", "twelve")
+
+	_, err := req.ParamInt("id")
+
+	assert.Equal(t, "path parameter 'id' must be an integer", err.Error())
+	assert.Equal(t, http.StatusBadRequest, response.BadRequest(err).StatusCode())
+}
+
+func Test_ParamInt_OutOfRange(t *testing.T) {
+	req := requestWithParam("id", "0")
+
+	_, err := req.ParamInt64("id", Min(1))
+
+	assert.Equal(t, "path parameter 'id' must be at least 1", err.Error())
+}
+
+func Test_ParamInt_Missing(t *testing.T) {
+	req := requestWithParam("id", "12")
+
+	_, err := req.ParamInt("user")
+
+	assert.Equal(t, "path parameter 'user' is missing", err.Error())
+}
+
+func Test_ParamBool(t *testing.T) {
+	value, err := requestWithParam("active", "true").ParamBool("active")
+	assert.NoError(t, err)
+	assert.True(t, value)
+
+	_, err = requestWithParam("active", "yes").ParamBool("active")
+	assert.Equal(t, "path parameter 'active' must be a boolean", err.Error())
+}
+
+func Test_ParamUUID(t *testing.T) {
+	value, err := requestWithParam("id", "123E4567-E89B-12D3-A456-426614174000").ParamUUID("id")
+	assert.NoError(t, err)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", value)
+
+	_, err = requestWithParam("id", "123e4567e89b12d3a456426614174000").ParamUUID("id")
+	assert.Equal(t, "path parameter 'id' must be an UUID", err.Error())
+}
+
+func Test_ParamTime(t *testing.T) {
+	day, err := requestWithParam("day", "2021-02-16").ParamTime("day", Layout("2006-01-02"))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 2, 16, 0, 0, 0, 0, time.UTC), day)
+
+	_, err = requestWithParam("day", "2021-02-16").ParamTime("day", Layout("2006-01-02"), After(day))
+	assert.Equal(t, "path parameter 'day' must be after 2021-02-16", err.Error())
+
+	_, err = requestWithParam("day", "16.02.2021").ParamTime("day")
+	assert.Equal(t, "path parameter 'day' must be a time in format '2006-01-02T15:04:05Z07:00'", err.Error())
+}
+
+func Test_Param_ConstraintOfOtherKind(t *testing.T) {
+	_, err := requestWithParam("id", "12").ParamInt("id", Min(1), Layout("2006"))
+	assert.Equal(t, "constraint Layout cannot be applied to path parameter 'id'", err.Error())
+	assert.Equal(t, http.StatusInternalServerError, response.BadRequest(err).StatusCode())
+
+	_, err = requestWithParam("day", "2021-02-16").ParamTime("day", Max(10))
+	assert.Equal(t, "constraint Max cannot be applied to path parameter 'day'", err.Error())
+	assert.Equal(t, http.StatusInternalServerError, response.BadRequest(err).StatusCode())
+}
+
+func requestWithParam(key, value string) Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	return wrapRequest(r, Options{}.withDefaults())
+}
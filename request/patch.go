@@ -0,0 +1,172 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+const (
+	// MergePatchMediaType media type of JSON Merge Patch documents (RFC 7396).
+	MergePatchMediaType = "application/merge-patch+json"
+
+	// JSONPatchMediaType media type of JSON Patch documents (RFC 6902).
+	JSONPatchMediaType = "application/json-patch+json"
+)
+
+var errPatchTarget = errors.New("patch target must be a non-nil pointer")
+
+func (r nativeRequest) MergePatch(existing interface{}) error {
+	return r.patch(existing, MergePatchMediaType, func(doc interface{}, body []byte) (interface{}, error) {
+		patch, err := decodeJSON(body)
+		if err != nil {
+			return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+		}
+
+		return mergePatch(doc, patch), nil
+	})
+}
+
+func (r nativeRequest) JSONPatch(existing interface{}) error {
+	return r.patch(existing, JSONPatchMediaType, func(doc interface{}, body []byte) (interface{}, error) {
+		var operations []patchOperation
+		if err := json.Unmarshal(body, &operations); err != nil {
+			return nil, response.NewProblem(http.StatusBadRequest).WithDetail(err.Error())
+		}
+
+		return jsonPatch(doc, operations)
+	})
+}
+
+// patch applies patch document of the media type from body to existing value, which is
+// replaced by the result and validated.
+func (r nativeRequest) patch(existing interface{}, mediaType string, apply func(doc interface{}, body []byte) (interface{}, error)) error {
+	v := reflect.ValueOf(existing)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errPatchTarget
+	}
+	if r.request.Body == nil {
+		return errors.New("empty body from request")
+	}
+
+	contentType, _, err := mime.ParseMediaType(r.request.Header.Get("content-type"))
+	if err != nil || contentType != mediaType {
+		return response.NewProblem(http.StatusUnsupportedMediaType).
+			WithDetail(fmt.Sprintf("content type must be '%s'", mediaType)).
+			WithExtension("supported", []string{mediaType})
+	}
+
+	options := r.bodyOptions()
+	limited, err := r.limitBody(options.MaxSize)
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(limited)
+	if limited.exceeded {
+		return entityTooLarge(options.MaxSize)
+	}
+	if err != nil {
+		return err
+	}
+
+	current, err := json.Marshal(existing)
+	if err != nil {
+		return err
+	}
+	doc, err := decodeJSON(current)
+	if err != nil {
+		return err
+	}
+
+	patched, err := apply(doc, body)
+	if err != nil {
+		return err
+	}
+
+	result, err := json.Marshal(patched)
+	if err != nil {
+		return err
+	}
+
+	// decoded into a fresh value, so fields removed by patch don't keep their previous
+	// values, then set on a copy of existing, so fields hidden from JSON keep theirs and
+	// existing stays untouched when the result is rejected
+	decoded := reflect.New(v.Elem().Type())
+	if err := codec.JSON.(codec.OptionsDecoder).DecodeWithOptions(bytes.NewReader(result), decoded.Interface(), options.decodeOptions()); err != nil {
+		return response.NewProblem(http.StatusUnprocessableEntity).WithDetail(err.Error())
+	}
+	patchedValue := reflect.New(v.Elem().Type())
+	patchedValue.Elem().Set(v.Elem())
+	setVisible(patchedValue.Elem(), decoded.Elem())
+
+	if isStruct(existing) {
+		if err := validationError(r.options.Validator.Struct(patchedValue.Interface()), patchedValue.Interface(), "json"); err != nil {
+			return err
+		}
+	}
+
+	v.Elem().Set(patchedValue.Elem())
+	return nil
+}
+
+// setVisible sets fields of dst encoded to JSON to their values in src. Unexported fields
+// and fields tagged `json:"-"` keep their values.
+func setVisible(dst, src reflect.Value) {
+	if dst.Kind() != reflect.Struct {
+		dst.Set(src)
+		return
+	}
+
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		tag := field.Tag.Get("json")
+		switch {
+		case tag == "-":
+		case field.Anonymous && field.Type.Kind() == reflect.Struct && strings.Split(tag, ",")[0] == "":
+			setVisible(dst.Field(i), src.Field(i))
+		case dst.Field(i).CanSet():
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+// decodeJSON decodes document into maps, slices and scalars keeping numbers intact.
+func decodeJSON(b []byte) (interface{}, error) {
+	var doc interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	return doc, d.Decode(&doc)
+}
+
+// mergePatch applies JSON Merge Patch to target (RFC 7396, section 2).
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
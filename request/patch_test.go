@@ -0,0 +1,175 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+type patchedArticle struct {
+	Title  string   `json:"title" validate:"required"`
+	Tags   []string `json:"tags,omitempty"`
+	Views  int      `json:"views"`
+	Author *struct {
+		Name  string `json:"name"`
+		Email string `json:"email,omitempty"`
+	} `json:"author,omitempty"`
+}
+
+func patchArticle(apply func(Request, *patchedArticle) error) (*patchedArticle, http.HandlerFunc) {
+	article := &patchedArticle{}
+	return article, HandleAction(func(r Request) response.Response {
+		*article = patchedArticle{Title: "lorem", Tags: []string{"a", "b"}, Views: 3}
+		article.Author = &struct {
+			Name  string `json:"name"`
+			Email string `json:"email,omitempty"`
+		}{Name: "John", Email: "john@example.com"}
+
+		if err := apply(r, article); err != nil {
+			return response.BadRequest(err)
+		}
+		return response.Ok(article)
+	})
+}
+
+func mergePatchRequest(body string) *http.Request {
+	request, _ := http.NewRequest("PATCH", "/", strings.NewReader(body))
+	request.Header.Set("content-type", MergePatchMediaType)
+	return request
+}
+
+func Test_MergePatch(t *testing.T) {
+	article, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.MergePatch(a) })
+
+	response := httpResponse(handler, mergePatchRequest(`{"title":"ipsum","tags":null,"author":{"email":null}}`))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "ipsum", article.Title)
+	assert.Nil(t, article.Tags)
+	assert.Equal(t, 3, article.Views)
+	assert.Equal(t, "John", article.Author.Name)
+	assert.Equal(t, "", article.Author.Email)
+}
+
+func Test_MergePatch_Validation(t *testing.T) {
+	_, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.MergePatch(a) })
+
+	response := httpResponse(handler, mergePatchRequest(`{"title":null}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Contains(t, response.Body.String(), `"field":"title"`)
+}
+
+func Test_MergePatch_WrongType(t *testing.T) {
+	article, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.MergePatch(a) })
+
+	response := httpResponse(handler, mergePatchRequest(`{"views":"many"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Equal(t, "lorem", article.Title)
+	assert.Equal(t, 3, article.Views)
+}
+
+func Test_MergePatch_Malformed(t *testing.T) {
+	_, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.MergePatch(a) })
+
+	response := httpResponse(handler, mergePatchRequest(`{"title":`))
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func Test_MergePatch_UnsupportedMediaType(t *testing.T) {
+	_, handler := patchArticle(func(r Request, a *patchedArticle) error { return r.MergePatch(a) })
+
+	request, _ := http.NewRequest("PATCH", "/", strings.NewReader(`{"title":"ipsum"}`))
+	request.Header.Set("content-type", "application/json")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.Code)
+	assert.Contains(t, response.Body.String(), MergePatchMediaType)
+}
+
+func Test_MergePatch_MaxSize(t *testing.T) {
+	handler := HandleAction(func(r Request) response.Response {
+		article := patchedArticle{Title: "lorem"}
+		if err := r.MergePatch(&article); err != nil {
+			return response.BadRequest(err)
+		}
+		return response.Ok(article)
+	}, Options{Body: BodyOptions{MaxSize: 8}})
+
+	response := httpResponse(handler, mergePatchRequest(`{"title":"lorem-ipsum"}`))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+}
+
+func Test_MergePatch_Target(t *testing.T) {
+	request := wrapRequest(mergePatchRequest(`{}`), firstOptions(nil))
+
+	assert.Equal(t, errPatchTarget, request.MergePatch(patchedArticle{}))
+}
+
+type patchedAccount struct {
+	patchedMeta
+	Name     string `json:"name"`
+	Password string `json:"-"`
+	version  int
+}
+
+type patchedMeta struct {
+	Role     string `json:"role"`
+	Internal string `json:"-"`
+}
+
+func Test_MergePatch_HiddenFields(t *testing.T) {
+	account := patchedAccount{
+		patchedMeta: patchedMeta{Role: "user", Internal: "audit"},
+		Name:        "john",
+		Password:    "secret",
+		version:     7,
+	}
+	request := wrapRequest(mergePatchRequest(`{"name":"jane","role":null}`), firstOptions(nil))
+
+	assert.Nil(t, request.MergePatch(&account))
+	assert.Equal(t, patchedAccount{
+		patchedMeta: patchedMeta{Role: "", Internal: "audit"},
+		Name:        "jane",
+		Password:    "secret",
+		version:     7,
+	}, account)
+}
+
+func Test_mergePatch(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range tests {
+		target, _ := decodeJSON([]byte(test.target))
+		patch, _ := decodeJSON([]byte(test.patch))
+		expected, _ := decodeJSON([]byte(test.result))
+
+		assert.Equal(t, expected, mergePatch(target, patch), test.patch)
+	}
+}
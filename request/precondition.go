@@ -0,0 +1,62 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var (
+	errPreconditionRequired = errors.New("request must be conditional, send If-Match or If-Unmodified-Since header")
+	errETagMismatch         = errors.New("resource has changed, its entity tag doesn't match If-Match header")
+	errModifiedSince        = errors.New("resource has been modified since time from If-Unmodified-Since header")
+)
+
+// RequirePrecondition rejects PUT, PATCH and DELETE requests without If-Match or
+// If-Unmodified-Since header with Precondition Required, so clients cannot overwrite
+// changes they haven't seen. Handlers check the headers with Request.Precondition.
+func RequirePrecondition(r Request) (context.Context, response.Response) {
+	switch r.Request().Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return r.Context(), nil
+	}
+
+	if _, ok := r.IfUnmodifiedSince(); !ok && len(r.IfMatch()) == 0 {
+		return r.Context(), response.PreconditionRequired(errPreconditionRequired)
+	}
+
+	return r.Context(), nil
+}
+
+func (r nativeRequest) IfMatch() []string {
+	ifMatch := r.request.Header.Get("if-match")
+	if ifMatch == "*" {
+		return []string{"*"}
+	}
+
+	return parseETags(ifMatch)
+}
+
+func (r nativeRequest) IfUnmodifiedSince() (time.Time, bool) {
+	t, err := http.ParseTime(r.request.Header.Get("if-unmodified-since"))
+	return t, err == nil
+}
+
+func (r nativeRequest) Precondition(etag string, lastModified time.Time) error {
+	if ifMatch := r.request.Header.Get("if-match"); ifMatch != "" {
+		if etag == "" || !matchETag(ifMatch, response.QuoteETag(etag), true) {
+			return response.NewProblem(http.StatusPreconditionFailed).WithDetail(errETagMismatch.Error())
+		}
+		return nil
+	}
+
+	if since, ok := r.IfUnmodifiedSince(); ok && !lastModified.IsZero() && lastModified.Truncate(time.Second).After(since) {
+		return response.NewProblem(http.StatusPreconditionFailed).WithDetail(errModifiedSince.Error())
+	}
+
+	return nil
+}
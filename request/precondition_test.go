@@ -0,0 +1,79 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+var updatedAt = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+func updateHandler(r Request) response.Response {
+	if err := r.Precondition("v2", updatedAt); err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	return response.Ok("updated")
+}
+
+func Test_RequirePrecondition(t *testing.T) {
+	handler := HandleContext(RequirePrecondition)(HandleAction(updateHandler))
+
+	tests := []struct {
+		method  string
+		headers map[string]string
+		status  int
+	}{
+		{"PUT", nil, http.StatusPreconditionRequired},
+		{"DELETE", nil, http.StatusPreconditionRequired},
+		{"GET", nil, http.StatusOK},
+		{"POST", nil, http.StatusOK},
+		{"PATCH", map[string]string{"if-match": `"v2"`}, http.StatusOK},
+		{"PATCH", map[string]string{"if-match": `"v1", "v2"`}, http.StatusOK},
+		{"PATCH", map[string]string{"if-match": "*"}, http.StatusOK},
+		{"PATCH", map[string]string{"if-match": `"v1"`}, http.StatusPreconditionFailed},
+		{"PATCH", map[string]string{"if-match": `W/"v2"`}, http.StatusPreconditionFailed},
+		{"PUT", map[string]string{"if-unmodified-since": updatedAt.Format(http.TimeFormat)}, http.StatusOK},
+		{"PUT", map[string]string{"if-unmodified-since": updatedAt.Add(-time.Minute).Format(http.TimeFormat)}, http.StatusPreconditionFailed},
+		{"PUT", map[string]string{"if-unmodified-since": "yesterday"}, http.StatusPreconditionRequired},
+		{"PUT", map[string]string{"if-match": `"v2"`, "if-unmodified-since": updatedAt.Add(-time.Minute).Format(http.TimeFormat)}, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		request, _ := http.NewRequest(test.method, "/", nil)
+		for name, value := range test.headers {
+			request.Header.Set(name, value)
+		}
+		response := httpResponse(handler.ServeHTTP, request)
+
+		assert.Equal(t, test.status, response.Code, test.method, test.headers)
+		if test.status >= http.StatusBadRequest {
+			assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
+		}
+	}
+}
+
+func Test_Precondition_MissingResource(t *testing.T) {
+	request, _ := http.NewRequest("PUT", "/", nil)
+	request.Header.Set("if-match", "*")
+
+	err := wrapRequest(request, firstOptions(nil)).Precondition("", time.Time{})
+
+	assert.Equal(t, http.StatusPreconditionFailed, err.(response.Problem).Status)
+}
+
+func Test_Request_Preconditions(t *testing.T) {
+	request, _ := http.NewRequest("PUT", "/", nil)
+	request.Header.Set("if-match", `"a", W/"b"`)
+	request.Header.Set("if-unmodified-since", updatedAt.Format(http.TimeFormat))
+	r := wrapRequest(request, firstOptions(nil))
+
+	since, ok := r.IfUnmodifiedSince()
+
+	assert.Equal(t, []string{`"a"`, `W/"b"`}, r.IfMatch())
+	assert.True(t, ok)
+	assert.Equal(t, updatedAt, since)
+}
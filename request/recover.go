@@ -0,0 +1,62 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime/debug"
+
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// Logger receives panics recovered from handlers, *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+var defaultLogger Logger = log.New(os.Stderr, "", log.LstdFlags)
+
+// callAction runs handler converting its panic into Internal Server Error response.
+func callAction(cb func(req Request) response.Response, req nativeRequest) (res response.Response) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			res = req.options.panicResponse(req.request, recovered)
+		}
+	}()
+
+	return cb(req)
+}
+
+// callContext runs context handler converting its panic into Internal Server Error response.
+func callContext(cb func(req Request) (context.Context, response.Response), req nativeRequest) (ctx context.Context, res response.Response) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			ctx, res = req.Context(), req.options.panicResponse(req.request, recovered)
+		}
+	}()
+
+	return cb(req)
+}
+
+// panicResponse logs recovered panic with stack trace and describes it as a problem.
+// Details of panic are exposed to clients in debug mode only.
+func (o Options) panicResponse(r *http.Request, recovered interface{}) response.Response {
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	stack := debug.Stack()
+	o.Logger.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, recovered, stack)
+
+	problem := response.NewProblem(http.StatusInternalServerError).
+		WithDetail(response.RedactedDetail)
+	if o.Debug {
+		problem = problem.
+			WithDetail(fmt.Sprint(recovered)).
+			WithExtension("stack", string(stack))
+	}
+
+	return problem
+}
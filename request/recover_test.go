@@ -0,0 +1,66 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_Recover_Action(t *testing.T) {
+	var logs bytes.Buffer
+	handler := HandleAction(panicHandler, Options{Logger: log.New(&logs, "", 0)})
+
+	request, _ := http.NewRequest("GET", "/orders", nil)
+	request.Header.Set("accept", "application/xml")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "application/problem+xml", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "<detail>the server encountered an unexpected condition</detail>")
+	assert.NotContains(t, response.Body.String(), "database is gone")
+	assert.Contains(t, logs.String(), "panic serving GET /orders: database is gone")
+	assert.Contains(t, logs.String(), "recover_test.go")
+}
+
+func Test_Recover_Debug(t *testing.T) {
+	handler := HandleAction(panicHandler, Options{Logger: log.New(&bytes.Buffer{}, "", 0), Debug: true})
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Contains(t, response.Body.String(), "\"detail\":\"database is gone\"")
+	assert.Contains(t, response.Body.String(), "\"stack\":\"goroutine")
+}
+
+func Test_Recover_Context(t *testing.T) {
+	handler := HandleAction(collectionHandler)
+	handlerToTest := HandleContext(func(r Request) (context.Context, response.Response) {
+		panic("context is gone")
+	}, Options{Logger: log.New(&bytes.Buffer{}, "", 0)})(handler)
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	response := httptest.NewRecorder()
+	handlerToTest.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "application/problem+json", response.Header().Get("content-type"))
+}
+
+func Test_Recover_AbortHandler(t *testing.T) {
+	handler := HandleAction(func(r Request) response.Response { panic(http.ErrAbortHandler) })
+
+	request, _ := http.NewRequest("GET", "/", nil)
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { httpResponse(handler, request) })
+}
+
+func panicHandler(r Request) response.Response {
+	panic("database is gone")
+}
@@ -0,0 +1,154 @@
+package request
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// validationError converts errors of go-playground validator into a response listing
+// every failed field, named the same way the client named it in the request body.
+// Other errors are returned untouched.
+func validationError(err error, body interface{}, tagKey string) error {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	fields := make([]response.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		field := fieldPath(reflect.TypeOf(body), fieldError.StructNamespace(), tagKey)
+		fields = append(fields, response.FieldError{
+			Field:   field,
+			Tag:     fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: fieldMessage(field, fieldError),
+		})
+	}
+
+	return response.ValidationFailed(validationDetail(tagKey), fields...)
+}
+
+// validationDetail describes failed validation without naming Go types or fields, which
+// are listed by field errors the way the client named them.
+func validationDetail(tagKey string) string {
+	switch tagKey {
+	case "query":
+		return "query parameters failed validation"
+	case "header":
+		return "headers failed validation"
+	case "cookie":
+		return "cookies failed validation"
+	default:
+		return "request body failed validation"
+	}
+}
+
+// fieldPath translates struct namespace reported by validator, e.g. "User.Items[0].ID",
+// into the path built from tagKey names of fields, e.g. "items[0].id".
+func fieldPath(t reflect.Type, namespace string, tagKey string) string {
+	segments := strings.Split(namespace, ".")
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments[1:] {
+		name, index := segment, ""
+		if i := strings.Index(segment, "["); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+
+		t = indirectType(t)
+		if t == nil || t.Kind() != reflect.Struct {
+			path = append(path, segment)
+			t = nil
+			continue
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			path = append(path, segment)
+			t = nil
+			continue
+		}
+
+		if fieldName := tagName(field, tagKey); fieldName != "" {
+			path = append(path, fieldName+index)
+		}
+
+		t = field.Type
+		for i := strings.Count(index, "["); i > 0; i-- {
+			t = indirectType(t)
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				t = t.Elem()
+			}
+		}
+	}
+
+	return strings.Join(path, ".")
+}
+
+// tagName returns name of the field used by tagKey encoding. Untagged embedded structs
+// are flattened by encoders, so they have no name of their own.
+func tagName(field reflect.StructField, tagKey string) string {
+	name := strings.Split(field.Tag.Get(tagKey), ",")[0]
+	if i := strings.LastIndex(name, ">"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	switch {
+	case name == "-":
+		return field.Name
+	case name != "":
+		return name
+	case field.Anonymous:
+		return ""
+	default:
+		return field.Name
+	}
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func fieldMessage(field string, fieldError validator.FieldError) string {
+	unit := ""
+	switch fieldError.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s%s", field, fieldError.Param(), unit)
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s%s", field, fieldError.Param(), unit)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s%s", field, fieldError.Param(), unit)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s%s", field, fieldError.Param(), unit)
+	case "len":
+		if unit == "" {
+			return fmt.Sprintf("%s must be equal to %s", field, fieldError.Param())
+		}
+		return fmt.Sprintf("%s must be exactly %s%s long", field, fieldError.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, fieldError.Param())
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	}
+
+	if fieldError.Param() != "" {
+		return fmt.Sprintf("%s failed on the '%s=%s' rule", field, fieldError.Tag(), fieldError.Param())
+	}
+	return fmt.Sprintf("%s failed on the '%s' rule", field, fieldError.Tag())
+}
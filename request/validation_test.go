@@ -0,0 +1,70 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_Validation_FieldsInJSON(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(orderToResponse))
+	body, _ := json.Marshal(map[string]interface{}{
+		"customer": map[string]string{"email": "not-an-email"},
+		"items":    []map[string]int{{"quantity": 2}, {"quantity": 0}},
+	})
+
+	request, _ := http.NewRequest("POST", "/", bytes.NewReader(body))
+	request.Header.Set("content-type", "application/json")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Contains(t, response.Body.String(), "{\"field\":\"customer.email\",\"tag\":\"email\",\"message\":\"customer.email must be a valid email address\"}")
+	assert.Contains(t, response.Body.String(), "{\"field\":\"items[1].quantity\",\"tag\":\"gte\",\"param\":\"1\",\"message\":\"items[1].quantity must be at least 1\"}")
+	assert.Contains(t, response.Body.String(), "{\"field\":\"reference\",\"tag\":\"required\",\"message\":\"reference is required\"}")
+}
+
+func Test_Validation_FieldsInXML(t *testing.T) {
+	handler := http.HandlerFunc(HandleAction(orderToResponse))
+	body := "<order><ref>A1</ref><customer><mail>lorem@ipsum.com</mail></customer></order>"
+
+	request, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+	request.Header.Set("content-type", "application/xml")
+	response := httpResponse(handler, request)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Contains(t, response.Body.String(), "<errors><error><field>line</field><tag>min</tag><param>1</param><message>line must be at least 1 items</message></error></errors>")
+}
+
+func Test_Validation_OtherErrorsUntouched(t *testing.T) {
+	err := validationError(errFoo, &order{}, "json")
+
+	assert.Equal(t, errFoo, err)
+}
+
+func orderToResponse(r Request) response.Response {
+	body := order{}
+	if err := r.Body(&body); err != nil {
+		return response.BadRequest(err)
+	}
+
+	return response.Ok(body)
+}
+
+type order struct {
+	Reference string      `json:"reference" xml:"ref" validate:"required"`
+	Customer  *customer   `json:"customer" xml:"customer" validate:"required"`
+	Items     []orderItem `json:"items" xml:"line" validate:"min=1,dive"`
+}
+
+type customer struct {
+	Email string `json:"email" xml:"mail" validate:"email"`
+}
+
+type orderItem struct {
+	Quantity int `json:"quantity" xml:"quantity" validate:"gte=1"`
+}
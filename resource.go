@@ -0,0 +1,84 @@
+package restful
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"gitlab.com/devmint/go-restful/context/paginate"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// Lister lists the collection of resource, served by GET on pattern of resource.
+type Lister interface {
+	List(r request.Request) response.Response
+}
+
+// Creator creates a new item of resource, served by POST on pattern of resource.
+type Creator interface {
+	Create(r request.Request) response.Response
+}
+
+// Shower shows a single item of resource, served by GET on pattern/{id}.
+type Shower interface {
+	Show(r request.Request) response.Response
+}
+
+// Updater replaces a single item of resource, served by PUT on pattern/{id}.
+type Updater interface {
+	Update(r request.Request) response.Response
+}
+
+// Patcher partially updates a single item of resource, served by PATCH on pattern/{id}.
+type Patcher interface {
+	Patch(r request.Request) response.Response
+}
+
+// Deleter deletes a single item of resource, served by DELETE on pattern/{id}.
+type Deleter interface {
+	Delete(r request.Request) response.Response
+}
+
+func (router restfulRouter) Resource(pattern string, controller interface{}) Router {
+	switch controller.(type) {
+	case Lister, Creator, Shower, Updater, Patcher, Deleter:
+	default:
+		panic(fmt.Sprintf("restful: controller %T of resource '%s' implements none of Lister, Creator, Shower, Updater, Patcher and Deleter", controller, pattern))
+	}
+
+	return router.Route(pattern, func(r Router) {
+		resource := r.(restfulRouter)
+		resource.r.NotFound(request.HandleAction(resource.resourceNotFound, resource.options))
+
+		if c, ok := controller.(Lister); ok {
+			r.With(paginate.Paginate(paginate.DefaultTake, paginate.DefaultSkip)).Get("/", c.List)
+		}
+		if c, ok := controller.(Creator); ok {
+			r.Post("/", c.Create)
+		}
+		if c, ok := controller.(Shower); ok {
+			r.Get("/{id}", c.Show)
+		}
+		if c, ok := controller.(Updater); ok {
+			r.Put("/{id}", c.Update)
+		}
+		if c, ok := controller.(Patcher); ok {
+			r.Patch("/{id}", c.Patch)
+		}
+		if c, ok := controller.(Deleter); ok {
+			r.Delete("/{id}", c.Delete)
+		}
+	})
+}
+
+// resourceNotFound answers paths of resource routed for no method at all, pattern and
+// pattern/{id} of controllers implementing none of their actions, with Method Not
+// Allowed. Other paths are not found.
+func (router restfulRouter) resourceNotFound(r request.Request) response.Response {
+	if path := strings.Trim(chi.RouteContext(r.Context()).RoutePath, "/"); !strings.Contains(path, "/") {
+		return router.fallbacks.handleMethodNotAllowed(r)
+	}
+
+	return router.fallbacks.handleNotFound(r)
+}
@@ -0,0 +1,151 @@
+package restful
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/context/paginate"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+type readOnlyController struct{}
+
+func (readOnlyController) List(r request.Request) response.Response {
+	params, _ := paginate.FromContext(r.Context())
+	return response.Ok(fmt.Sprintf("take %v skip %v", params.Take, params.Skip))
+}
+
+func (readOnlyController) Show(r request.Request) response.Response {
+	return response.Ok("show " + r.Param("id"))
+}
+
+type fullController struct {
+	readOnlyController
+}
+
+func (fullController) Create(r request.Request) response.Response { return response.Created() }
+func (fullController) Update(r request.Request) response.Response {
+	return response.Ok("update " + r.Param("id"))
+}
+func (fullController) Patch(r request.Request) response.Response {
+	return response.Ok("patch " + r.Param("id"))
+}
+func (fullController) Delete(r request.Request) response.Response { return response.NoContent() }
+
+func Test_Resource(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Resource("/users", fullController{})
+
+	tests := []struct {
+		method, target string
+		status         int
+		body           string
+	}{
+		{"GET", "/users", http.StatusOK, `{"data":"take 30 skip 0"}`},
+		{"GET", "/users?take=5&skip=10", http.StatusOK, `{"data":"take 5 skip 10"}`},
+		{"POST", "/users", http.StatusCreated, ""},
+		{"GET", "/users/12", http.StatusOK, `{"data":"show 12"}`},
+		{"PUT", "/users/12", http.StatusOK, `{"data":"update 12"}`},
+		{"PATCH", "/users/12", http.StatusOK, `{"data":"patch 12"}`},
+		{"DELETE", "/users/12", http.StatusNoContent, ""},
+	}
+
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest(test.method, test.target, nil)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, test.status, response.Code, test.method+" "+test.target)
+		if test.body != "" {
+			assert.Equal(t, test.body, response.Body.String())
+		}
+	}
+}
+
+func Test_Resource_Unimplemented(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Resource("/users", readOnlyController{})
+
+	tests := map[string]string{
+		"/users":    "GET",
+		"/users/12": "GET",
+	}
+
+	for target, allow := range tests {
+		for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+			response := httptest.NewRecorder()
+			request, _ := http.NewRequest(method, target, nil)
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusMethodNotAllowed, response.Code, method+" "+target)
+			assert.Equal(t, allow, response.Header().Get("allow"))
+		}
+	}
+}
+
+type showController struct{}
+
+func (showController) Show(r request.Request) response.Response { return response.Ok("show") }
+
+type listController struct{}
+
+func (listController) List(r request.Request) response.Response { return response.Ok("list") }
+
+func Test_Resource_PathWithoutActions(t *testing.T) {
+	tests := []struct {
+		controller interface{}
+		method     string
+		target     string
+		status     int
+		allow      string
+	}{
+		{showController{}, "GET", "/users/12", http.StatusOK, ""},
+		{showController{}, "GET", "/users", http.StatusMethodNotAllowed, ""},
+		{showController{}, "POST", "/users", http.StatusMethodNotAllowed, ""},
+		{showController{}, "DELETE", "/users/12", http.StatusMethodNotAllowed, "GET"},
+		{showController{}, "GET", "/users/12/avatar", http.StatusNotFound, ""},
+		{listController{}, "GET", "/users", http.StatusOK, ""},
+		{listController{}, "POST", "/users", http.StatusMethodNotAllowed, "GET"},
+		{listController{}, "GET", "/users/1", http.StatusMethodNotAllowed, ""},
+		{listController{}, "DELETE", "/users/1", http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, test := range tests {
+		router := NewRouter(chi.NewMux())
+		router.Resource("/users", test.controller)
+
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest(test.method, test.target, nil)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, test.status, response.Code, test)
+		assert.Equal(t, test.allow, response.Header().Get("allow"), test)
+	}
+}
+
+func Test_Resource_Routes(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	users := router.Resource("/users", readOnlyController{})
+	users.Get("/{id}/avatar", func(r request.Request) response.Response { return response.Ok() })
+
+	var routes []string
+	for _, route := range router.RouteInfos() {
+		routes = append(routes, route.Method+" "+route.Pattern)
+	}
+
+	assert.Equal(t, []string{"GET /users", "GET /users/{id}", "GET /users/{id}/avatar"}, routes)
+}
+
+func Test_Resource_NoActions(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+
+	assert.PanicsWithValue(t, "restful: controller struct {} of resource '/users' implements none of Lister, Creator, Shower, Updater, Patcher and Deleter", func() {
+		router.Resource("/users", struct{}{})
+	})
+	assert.Empty(t, router.RouteInfos())
+}
@@ -0,0 +1,117 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// RedactedDetail replaces details of errors without HTTP semantics and of recovered
+// panics, so internals of the server don't leak to clients.
+const RedactedDetail = "the server encountered an unexpected condition"
+
+// StatusCoder is implemented by errors which know status code of response describing
+// them, e.g. errors of service layer.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Detailer is implemented by errors which explain themselves to clients. Detail of
+// mapped errors is the status text otherwise, as messages of wrapped errors may leak
+// internals of the server.
+type Detailer interface {
+	ProblemDetail() string
+}
+
+// errorMapping turns matching error into a problem, false when error doesn't match.
+type errorMapping func(err error) (Problem, bool)
+
+// ErrorMap maps errors of service layer to problems, see RouterOptions of restful
+// router, Request.Error and DefaultErrorMap. Zero value is ready to use.
+type ErrorMap struct {
+	mu       sync.RWMutex
+	mappings []errorMapping
+}
+
+// MapError makes FromError describe errors matching target (see errors.Is) with status
+// code and optional problem type URI.
+func (m *ErrorMap) MapError(target error, statusCode int, problemType ...string) {
+	m.add(func(err error) (Problem, bool) {
+		if !errors.Is(err, target) {
+			return Problem{}, false
+		}
+		return mappedProblem(err, statusCode, problemType), true
+	})
+}
+
+// MapErrorType makes FromError of the map describe errors of type T (see errors.As)
+// with status code and optional problem type URI.
+func MapErrorType[T error](m *ErrorMap, statusCode int, problemType ...string) {
+	m.add(func(err error) (Problem, bool) {
+		var target T
+		if !errors.As(err, &target) {
+			return Problem{}, false
+		}
+		return mappedProblem(err, statusCode, problemType), true
+	})
+}
+
+func (m *ErrorMap) add(mapping errorMapping) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mappings = append(m.mappings, mapping)
+}
+
+func mappedProblem(err error, statusCode int, problemType []string) Problem {
+	p := NewProblem(statusCode).WithDetail(http.StatusText(statusCode))
+	var detailer Detailer
+	if errors.As(err, &detailer) {
+		p = p.WithDetail(detailer.ProblemDetail())
+	}
+	if len(problemType) == 1 {
+		p = p.WithType(problemType[0])
+	}
+
+	return p
+}
+
+// FromError picks response describing err. Problems are returned as they are, then
+// errors mapped with MapError and MapErrorType are checked in order of registration,
+// then errors implementing StatusCoder. Any other error is described as Internal Server
+// Error with redacted detail. Nil map maps no errors.
+func (m *ErrorMap) FromError(err error) Response {
+	var problem Problem
+	if errors.As(err, &problem) {
+		return problem
+	}
+
+	if m != nil {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		for _, mapping := range m.mappings {
+			if p, ok := mapping(err); ok {
+				return p
+			}
+		}
+	}
+
+	var coder StatusCoder
+	if errors.As(err, &coder) && coder.StatusCode() >= http.StatusBadRequest {
+		return mappedProblem(err, coder.StatusCode(), nil)
+	}
+
+	return NewProblem(http.StatusInternalServerError).WithDetail(RedactedDetail)
+}
+
+// DefaultErrorMap is used by FromError and by routers without error map of their own
+// (see request.Options.Errors). Map errors shared by the whole application on it, and
+// errors of a single router on the map of the router.
+var DefaultErrorMap = &ErrorMap{}
+
+// FromError picks response describing err with DefaultErrorMap, see ErrorMap.FromError.
+// Handlers of routers with their own error map should use Request.Error instead.
+func FromError(err error) Response {
+	return DefaultErrorMap.FromError(err)
+}
@@ -0,0 +1,90 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errUserMissing  = errors.New("user does not exist")
+	errUserConflict = errors.New("user already exists")
+)
+
+type quotaError struct{ limit int }
+
+func (e quotaError) Error() string { return fmt.Sprintf("quota of %d requests exceeded", e.limit) }
+func (e quotaError) ProblemDetail() string {
+	return fmt.Sprintf("You can send %d requests per minute.", e.limit)
+}
+
+type coderError struct{ status int }
+
+func (e coderError) Error() string   { return "coded error" }
+func (e coderError) StatusCode() int { return e.status }
+
+func errorMap() *ErrorMap {
+	m := &ErrorMap{}
+	m.MapError(errUserMissing, http.StatusNotFound)
+	m.MapError(errUserConflict, http.StatusConflict, "https://example.com/probs/user-exists")
+	MapErrorType[quotaError](m, http.StatusTooManyRequests)
+
+	return m
+}
+
+func Test_FromError_Sentinel(t *testing.T) {
+	res := errorMap().FromError(fmt.Errorf("loading profile: %w", errUserMissing))
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode())
+	assert.Equal(t, "Not Found", res.(Problem).Detail)
+	assert.Equal(t, blankType, res.(Problem).Type)
+}
+
+func Test_FromError_ProblemType(t *testing.T) {
+	res := errorMap().FromError(errUserConflict)
+
+	assert.Equal(t, http.StatusConflict, res.StatusCode())
+	assert.Equal(t, "https://example.com/probs/user-exists", res.(Problem).Type)
+}
+
+func Test_FromError_Type(t *testing.T) {
+	res := errorMap().FromError(fmt.Errorf("calling api: %w", quotaError{limit: 10}))
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode())
+	assert.Equal(t, "You can send 10 requests per minute.", res.(Problem).Detail)
+}
+
+func Test_FromError_StatusCoder(t *testing.T) {
+	res := FromError(fmt.Errorf("wrapped: %w", coderError{status: http.StatusGone}))
+
+	assert.Equal(t, http.StatusGone, res.StatusCode())
+	assert.Equal(t, "Gone", res.(Problem).Detail)
+}
+
+func Test_FromError_Problem(t *testing.T) {
+	problem := NewProblem(http.StatusPaymentRequired).WithDetail("pay first")
+
+	assert.Equal(t, problem, FromError(fmt.Errorf("wrapped: %w", problem)))
+}
+
+func Test_FromError_Default(t *testing.T) {
+	for _, err := range []error{errors.New("connection refused to 10.0.0.1"), coderError{status: http.StatusOK}, errUserMissing} {
+		res := FromError(err)
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode())
+		assert.Equal(t, RedactedDetail, res.(Problem).Detail)
+	}
+}
+
+func Test_FromError_DefaultErrorMap(t *testing.T) {
+	errSessionExpired := errors.New("session expired")
+	DefaultErrorMap.MapError(errSessionExpired, http.StatusUnauthorized)
+
+	res := FromError(fmt.Errorf("loading session: %w", errSessionExpired))
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	assert.Equal(t, "Unauthorized", res.(Problem).Detail)
+}
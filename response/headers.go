@@ -1,5 +1,14 @@
 package response
 
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// rawHeaders headers of response keyed by lowercase names. Validators of conditional
+// requests are set with its WithETag and WithLastModified, e.g.
+// res.Header().WithETag(version), so they're available for any Response.
 type rawHeaders map[string]string
 
 type header interface {
@@ -8,9 +17,44 @@ type header interface {
 }
 
 func (h rawHeaders) WithHeader(key, value string) {
-	h[key] = value
+	h[strings.ToLower(key)] = value
 }
 
 func (h rawHeaders) Header() rawHeaders {
 	return h
 }
+
+func (h rawHeaders) clone() rawHeaders {
+	cloned := make(rawHeaders, len(h))
+	for key, value := range h {
+		cloned[key] = value
+	}
+
+	return cloned
+}
+
+// WithETag sets entity tag of the response, quoted with QuoteETag.
+func (h rawHeaders) WithETag(etag string) {
+	h.WithHeader("etag", QuoteETag(etag))
+}
+
+// WithLastModified sets time the resource was last modified.
+func (h rawHeaders) WithLastModified(t time.Time) {
+	h.WithHeader("last-modified", t.UTC().Format(http.TimeFormat))
+}
+
+// Get returns value of the header regardless of case of its name.
+func (h rawHeaders) Get(key string) string {
+	return h[strings.ToLower(key)]
+}
+
+// QuoteETag quotes opaque part of entity tag unless it's quoted already, weak tags keep
+// their prefix, e.g. W/v2 becomes W/"v2".
+func QuoteETag(etag string) string {
+	opaque := strings.TrimPrefix(etag, "W/")
+	if strings.HasPrefix(opaque, `"`) {
+		return etag
+	}
+
+	return etag[:len(etag)-len(opaque)] + `"` + opaque + `"`
+}
@@ -0,0 +1,43 @@
+package response
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Headers_WithETag(t *testing.T) {
+	tests := map[string]string{
+		"v1":      `"v1"`,
+		`"v1"`:    `"v1"`,
+		`W/"v1"`:  `W/"v1"`,
+		"W/v1":    `W/"v1"`,
+		"a b c 1": `"a b c 1"`,
+	}
+
+	for etag, expected := range tests {
+		res := Ok()
+		res.Header().WithETag(etag)
+
+		assert.Equal(t, expected, res.Header()["etag"])
+	}
+}
+
+func Test_Headers_WithLastModified(t *testing.T) {
+	res := Ok()
+	res.Header().WithLastModified(time.Date(2021, 2, 3, 4, 5, 6, 0, time.FixedZone("CET", 3600)))
+
+	assert.Equal(t, "Wed, 03 Feb 2021 03:05:06 GMT", res.Header()["last-modified"])
+}
+
+func Test_Headers_Get(t *testing.T) {
+	res := Ok()
+	res.WithHeader("x-request-id", "12")
+
+	res.WithHeader("X-Request-ID", "13")
+
+	assert.Equal(t, rawHeaders{"x-request-id": "13"}, res.Header())
+	assert.Equal(t, "13", res.Header().Get("X-Request-ID"))
+	assert.Equal(t, "", res.Header().Get("ETag"))
+}
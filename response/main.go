@@ -1,9 +1,12 @@
 package response
 
 import (
-	"encoding/json"
+	"bytes"
 	"encoding/xml"
+	"fmt"
 	"net/http"
+
+	"gitlab.com/devmint/go-restful/codec"
 )
 
 var (
@@ -134,6 +137,14 @@ var (
 	// ExpectationFailed (HTTP 417)
 	// The expectation given in an Expect request-header field could not be met by this server, or, if the server is a proxy, the server has unambiguous evidence that the request could not be met by the next-hop server.
 	ExpectationFailed = createErrorResponse(http.StatusExpectationFailed)
+
+	// UnprocessableEntity (HTTP 422)
+	// The server understands the content type of the request entity, and the syntax of the request entity is correct, but it was unable to process the contained instructions.
+	UnprocessableEntity = createErrorResponse(http.StatusUnprocessableEntity)
+
+	// PreconditionRequired (HTTP 428)
+	// The origin server requires the request to be conditional. Its typical use is to avoid the "lost update" problem, where a client GETs a resource's state, modifies it, and PUTs it back to the server, when meanwhile a third party has modified the state on the server, leading to a conflict.
+	PreconditionRequired = createErrorResponse(http.StatusPreconditionRequired)
 )
 
 var (
@@ -165,41 +176,32 @@ var (
 // Response Generic representation of data returned by HTTP resource
 type Response interface {
 	StatusCode() int
-	GetJSON() string
-	GetXML() string
 
-	header
-}
+	// Payload returns value encoded into the body of response, nil means empty body.
+	Payload() interface{}
 
-type errorMessage struct {
-	rawHeaders `json:"-" xml:"-"`
-	XMLName    xml.Name `json:"-" xml:"response"`
-	Type       string   `json:"type,omitempty" xml:"type"`
-	Title      string   `json:"title" xml:"title"`
-	Detail     string   `json:"detail" xml:"detail"`
-	Status     int      `json:"status" xml:"status"`
+	header
 }
 
-func (e errorMessage) StatusCode() int { return e.Status }
-
-func (e errorMessage) GetJSON() string { return toJSON(e) }
-
-func (e errorMessage) GetXML() string { return toXML(e) }
-
-func (e errorMessage) Error() string { return e.Detail }
-
 type dataResponse struct {
 	rawHeaders `json:"-" xml:"-"`
 	XMLName    xml.Name    `json:"-" xml:"response"`
 	Data       interface{} `json:"data" xml:"data"`
 	Status     int         `json:"-" xml:"-"`
+
+	// renderBody false for responses without body, e.g. No Content.
+	renderBody bool
 }
 
 func (o dataResponse) StatusCode() int { return o.Status }
 
-func (o dataResponse) GetJSON() string { return toJSON(o) }
+func (o dataResponse) Payload() interface{} {
+	if !o.renderBody {
+		return nil
+	}
 
-func (o dataResponse) GetXML() string { return toXML(o) }
+	return o
+}
 
 type redirectResponse struct {
 	rawHeaders `json:"-" xml:"-"`
@@ -208,9 +210,7 @@ type redirectResponse struct {
 
 func (o redirectResponse) StatusCode() int { return o.Status }
 
-func (o redirectResponse) GetJSON() string { return "" }
-
-func (o redirectResponse) GetXML() string { return "" }
+func (o redirectResponse) Payload() interface{} { return nil }
 
 func createDataResponse(statusCode int, renderBody bool) func(data ...interface{}) Response {
 	return func(data ...interface{}) Response {
@@ -226,13 +226,14 @@ func createDataResponse(statusCode int, renderBody bool) func(data ...interface{
 			Data:       body,
 			Status:     statusCode,
 			rawHeaders: rawHeaders{},
+			renderBody: renderBody,
 		}
 	}
 }
 
 func createErrorResponse(statusCode int) func(err error, msg ...string) Response {
 	return func(err error, msg ...string) Response {
-		errResponse, ok := err.(errorMessage)
+		errResponse, ok := err.(Problem)
 		if ok {
 			return errResponse
 		}
@@ -242,13 +243,7 @@ func createErrorResponse(statusCode int) func(err error, msg ...string) Response
 			errMessage = msg[0]
 		}
 
-		return errorMessage{
-			Type:       "http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html",
-			Title:      http.StatusText(statusCode),
-			Status:     statusCode,
-			Detail:     errMessage,
-			rawHeaders: rawHeaders{},
-		}
+		return NewProblem(statusCode).WithDetail(errMessage)
 	}
 }
 
@@ -257,26 +252,38 @@ func createRedirectResponse(statusCode int) func(url string) Response {
 		return redirectResponse{
 			Status: statusCode,
 			rawHeaders: rawHeaders{
-				"Location": url,
+				"location": url,
 			},
 		}
 	}
 }
 
-func toJSON(v interface{}) string {
-	b, err := json.Marshal(v)
-	if err != nil {
-		return "{}"
+// MediaType returns media type the response is served with when mediaType has been
+// negotiated with client, e.g. problem details are served as "application/problem+json".
+func MediaType(r Response, mediaType string) string {
+	if m, ok := r.(interface{ MediaType(string) string }); ok {
+		return m.MediaType(mediaType)
 	}
 
-	return string(b)
+	return mediaType
 }
 
-func toXML(v interface{}) string {
-	c, err := xml.Marshal(v)
-	if err != nil {
-		return ""
+// Encode renders payload of the response with codec registered for the media type.
+func Encode(r Response, mediaType string) ([]byte, error) {
+	payload := r.Payload()
+	if payload == nil {
+		return nil, nil
+	}
+
+	c, ok := codec.Lookup(mediaType)
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for media type '%s'", mediaType)
+	}
+
+	var b bytes.Buffer
+	if err := c.Encode(&b, payload); err != nil {
+		return nil, err
 	}
 
-	return string(c)
+	return b.Bytes(), nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/codec"
 )
 
 var (
@@ -16,37 +17,59 @@ var (
 	emptyXML  = "<response></response>"
 
 	errorsMsg        = errors.New("missing entity")
-	errorsJSON       = "{\"type\":\"http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html\",\"title\":\"Not Found\",\"detail\":\"missing entity\",\"status\":404}"
-	errorsJSONCustom = "{\"type\":\"http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html\",\"title\":\"Not Found\",\"detail\":\"not-found-test\",\"status\":404}"
-	errorsXML        = "<response><type>http://www.w3.org/Protocols/rfc2616/rfc2616-sec10.html</type><title>Not Found</title><detail>missing entity</detail><status>404</status></response>"
+	errorsJSON       = "{\"type\":\"about:blank\",\"title\":\"Not Found\",\"detail\":\"missing entity\",\"status\":404}"
+	errorsJSONCustom = "{\"type\":\"about:blank\",\"title\":\"Not Found\",\"detail\":\"not-found-test\",\"status\":404}"
+	errorsXML        = "<problem xmlns=\"urn:ietf:rfc:7807\"><type>about:blank</type><title>Not Found</title><detail>missing entity</detail><status>404</status></problem>"
+
+	validationJSON = "{\"type\":\"about:blank\",\"title\":\"Unprocessable Entity\",\"detail\":\"invalid body\",\"status\":422,\"errors\":[{\"field\":\"a.b\",\"tag\":\"required\",\"message\":\"a.b is required\"}]}"
+	validationXML  = "<problem xmlns=\"urn:ietf:rfc:7807\"><type>about:blank</type><title>Unprocessable Entity</title><detail>invalid body</detail><status>422</status><errors><error><field>a.b</field><tag>required</tag><message>a.b is required</message></error></errors></problem>"
 )
 
 func Test_EncodeResponse_ToJSON(t *testing.T) {
-	assert.Equal(t, validJSON, Ok(validResponse).GetJSON())
+	assert.Equal(t, validJSON, toJSON(Ok(validResponse)))
 }
 
 func Test_EncodeResponse_ToXML(t *testing.T) {
-	assert.Equal(t, validXML, Ok(validResponse).GetXML())
+	assert.Equal(t, validXML, toXML(Ok(validResponse)))
 }
 
 func Test_EncodeResponse_EmptyContent_ToJSON(t *testing.T) {
-	assert.Equal(t, emptyJSON, Ok().GetJSON())
+	assert.Equal(t, emptyJSON, toJSON(Ok()))
 }
 
 func Test_EncodeResponse_EmptyContent_ToXML(t *testing.T) {
-	assert.Equal(t, emptyXML, Ok().GetXML())
+	assert.Equal(t, emptyXML, toXML(Ok()))
+}
+
+func Test_EncodeResponse_NoContent(t *testing.T) {
+	for _, res := range []Response{NoContent(), NoContent(validResponse), ResetContent()} {
+		assert.Nil(t, res.Payload())
+		assert.Equal(t, "", toJSON(res))
+	}
 }
 
 func Test_EncodeError_ToJSON(t *testing.T) {
-	assert.Equal(t, errorsJSON, NotFound(errorsMsg).GetJSON())
+	assert.Equal(t, errorsJSON, toJSON(NotFound(errorsMsg)))
 }
 
 func Test_EncodeError_ToXML(t *testing.T) {
-	assert.Equal(t, errorsXML, NotFound(errorsMsg).GetXML())
+	assert.Equal(t, errorsXML, toXML(NotFound(errorsMsg)))
 }
 
 func Test_EncodeError_ToJSON_CustomMessage(t *testing.T) {
-	assert.Equal(t, errorsJSONCustom, NotFound(errorsMsg, "not-found-test").GetJSON())
+	assert.Equal(t, errorsJSONCustom, toJSON(NotFound(errorsMsg, "not-found-test")))
+}
+
+func Test_EncodeValidationFailed_ToJSON(t *testing.T) {
+	err := ValidationFailed("invalid body", FieldError{Field: "a.b", Tag: "required", Message: "a.b is required"})
+
+	assert.Equal(t, validationJSON, toJSON(BadRequest(err)))
+}
+
+func Test_EncodeValidationFailed_ToXML(t *testing.T) {
+	err := ValidationFailed("invalid body", FieldError{Field: "a.b", Tag: "required", Message: "a.b is required"})
+
+	assert.Equal(t, validationXML, toXML(BadRequest(err)))
 }
 
 func Test_CustomHeaders(t *testing.T) {
@@ -57,3 +80,13 @@ func Test_CustomHeaders(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "b", value)
 }
+
+func toJSON(r Response) string {
+	b, _ := Encode(r, codec.JSONMediaType)
+	return string(b)
+}
+
+func toXML(r Response) string {
+	b, _ := Encode(r, codec.XMLMediaType)
+	return string(b)
+}
@@ -0,0 +1,98 @@
+package response
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TakeParam and SkipParam name query params selecting a page, read by paginate.Paginate
+	// and set in links of Page.
+	TakeParam = "take"
+	SkipParam = "skip"
+)
+
+// PageMeta describes position of a page within the whole collection.
+type PageMeta struct {
+	Take  int `json:"take" xml:"take"`
+	Skip  int `json:"skip" xml:"skip"`
+	Total int `json:"total" xml:"total"`
+	Pages int `json:"pages" xml:"pages"`
+}
+
+type pageResponse struct {
+	rawHeaders `json:"-" xml:"-"`
+	XMLName    xml.Name    `json:"-" xml:"response"`
+	Data       interface{} `json:"data" xml:"data"`
+	Meta       PageMeta    `json:"meta" xml:"meta"`
+}
+
+func (o pageResponse) StatusCode() int { return http.StatusOK }
+
+func (o pageResponse) Payload() interface{} { return o }
+
+// Page (HTTP 200)
+// Items of a single page of collection with `total` items, rendered as `data` with `meta`
+// block describing the page. Link header (RFC 8288) points to the first, previous, next
+// and last pages by replacing `take` and `skip` query params of u, the URL of current
+// request. See paginate.Page reading them from the context.
+func Page(u *url.URL, items interface{}, take, skip, total int) Response {
+	meta := PageMeta{Take: take, Skip: skip, Total: total}
+	if take > 0 {
+		meta.Pages = (total + take - 1) / take
+	}
+
+	res := pageResponse{
+		rawHeaders: rawHeaders{},
+		Data:       items,
+		Meta:       meta,
+	}
+	if links := meta.links(u); links != "" {
+		res.WithHeader("Link", links)
+	}
+
+	return res
+}
+
+func (m PageMeta) links(u *url.URL) string {
+	if u == nil || m.Take <= 0 {
+		return ""
+	}
+
+	last := 0
+	if m.Pages > 1 {
+		last = (m.Pages - 1) * m.Take
+	}
+
+	links := []string{pageLink(u, "first", m.Take, 0)}
+	if m.Skip > 0 {
+		prev := m.Skip - m.Take
+		if prev < 0 {
+			prev = 0
+		}
+		if prev > last {
+			prev = last
+		}
+		links = append(links, pageLink(u, "prev", m.Take, prev))
+	}
+	if m.Skip+m.Take < m.Total {
+		links = append(links, pageLink(u, "next", m.Take, m.Skip+m.Take))
+	}
+	links = append(links, pageLink(u, "last", m.Take, last))
+
+	return strings.Join(links, ", ")
+}
+
+func pageLink(u *url.URL, rel string, take, skip int) string {
+	query := u.Query()
+	query.Set(TakeParam, strconv.Itoa(take))
+	query.Set(SkipParam, strconv.Itoa(skip))
+
+	link := *u
+	link.RawQuery = query.Encode()
+	return fmt.Sprintf("<%s>; rel=\"%s\"", link.String(), rel)
+}
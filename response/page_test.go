@@ -0,0 +1,55 @@
+package response
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pageJSON = "{\"data\":[\"test\",\"test\"],\"meta\":{\"take\":2,\"skip\":2,\"total\":5,\"pages\":3}}"
+	pageXML  = "<response><data>test</data><data>test</data><meta><take>2</take><skip>2</skip><total>5</total><pages>3</pages></meta></response>"
+)
+
+func Test_EncodePage_ToJSON(t *testing.T) {
+	assert.Equal(t, pageJSON, toJSON(Page(nil, validResponse, 2, 2, 5)))
+}
+
+func Test_EncodePage_ToXML(t *testing.T) {
+	assert.Equal(t, pageXML, toXML(Page(nil, validResponse, 2, 2, 5)))
+}
+
+func Test_Page_Links(t *testing.T) {
+	u, _ := url.Parse("/articles?q=go&skip=2&take=2")
+
+	tests := []struct {
+		take, skip, total int
+		links             string
+	}{
+		{2, 2, 5, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=0&take=2>; rel="prev", </articles?q=go&skip=4&take=2>; rel="next", </articles?q=go&skip=4&take=2>; rel="last"`},
+		{2, 0, 5, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=2&take=2>; rel="next", </articles?q=go&skip=4&take=2>; rel="last"`},
+		{2, 4, 5, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=2&take=2>; rel="prev", </articles?q=go&skip=4&take=2>; rel="last"`},
+		{2, 1, 5, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=0&take=2>; rel="prev", </articles?q=go&skip=3&take=2>; rel="next", </articles?q=go&skip=4&take=2>; rel="last"`},
+		{2, 0, 0, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=0&take=2>; rel="last"`},
+		{2, 10, 5, `</articles?q=go&skip=0&take=2>; rel="first", </articles?q=go&skip=4&take=2>; rel="prev", </articles?q=go&skip=4&take=2>; rel="last"`},
+		{0, 0, 5, ""},
+	}
+
+	for _, test := range tests {
+		res := Page(u, validResponse, test.take, test.skip, test.total)
+
+		assert.Equal(t, test.links, res.Header().Get("link"), test)
+		assert.Equal(t, 200, res.StatusCode())
+	}
+}
+
+func Test_Page_Pages(t *testing.T) {
+	tests := map[[2]int]int{{10, 0}: 0, {10, 1}: 1, {10, 10}: 1, {10, 11}: 2, {0, 11}: 0}
+
+	for params, pages := range tests {
+		res := Page(nil, nil, params[0], 0, params[1]).Payload().(pageResponse)
+
+		assert.Equal(t, pages, res.Meta.Pages, params)
+	}
+}
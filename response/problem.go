@@ -0,0 +1,274 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// ProblemJSON media type of problem details serialized to JSON (RFC 7807).
+	ProblemJSON = "application/problem+json"
+
+	// ProblemXML media type of problem details serialized to XML (RFC 7807).
+	ProblemXML = "application/problem+xml"
+
+	// problemNamespace is XML namespace of problem details defined in appendix A of RFC 7807.
+	problemNamespace = "urn:ietf:rfc:7807"
+
+	// blankType is type of problems without semantics beyond their status code (RFC 7807,
+	// section 4.2), used by every problem created by this package.
+	blankType = "about:blank"
+)
+
+// Problem machine-readable details of an error in HTTP response as defined by RFC 7807.
+// Problem is both Response and error, so it can be returned from services and passed
+// through error constructors like BadRequest(err) unchanged.
+type Problem struct {
+	rawHeaders
+
+	// Type URI reference identifying the problem type.
+	Type string
+	// Title short, human-readable summary of the problem type.
+	Title string
+	// Detail human-readable explanation specific to this occurrence of the problem.
+	Detail string
+	// Status HTTP status code generated by the origin server for this occurrence.
+	Status int
+	// Instance URI reference identifying the specific occurrence of the problem.
+	Instance string
+	// Errors fields of request which failed validation.
+	Errors []FieldError
+
+	extensions map[string]interface{}
+}
+
+// FieldError describes single field of request which failed validation.
+type FieldError struct {
+	// Field is a path to the field as seen by client, e.g. "address.street" or "items[0].id".
+	Field   string `json:"field" xml:"field"`
+	Tag     string `json:"tag" xml:"tag"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+// NewProblem creates problem details for the status code with "about:blank" type.
+func NewProblem(statusCode int) Problem {
+	return Problem{
+		Type:       blankType,
+		Title:      http.StatusText(statusCode),
+		Status:     statusCode,
+		rawHeaders: rawHeaders{},
+	}
+}
+
+// ValidationFailed (HTTP 422) creates problem listing every field of request which
+// failed validation.
+func ValidationFailed(detail string, fields ...FieldError) Problem {
+	p := NewProblem(http.StatusUnprocessableEntity).WithDetail(detail)
+	p.Errors = fields
+
+	return p
+}
+
+// WithType sets URI identifying the problem type.
+func (p Problem) WithType(uri string) Problem {
+	p = p.clone()
+	p.Type = uri
+	return p
+}
+
+// WithTitle sets summary of the problem type.
+func (p Problem) WithTitle(title string) Problem {
+	p = p.clone()
+	p.Title = title
+	return p
+}
+
+// WithDetail sets explanation specific to this occurrence of the problem.
+func (p Problem) WithDetail(detail string) Problem {
+	p = p.clone()
+	p.Detail = detail
+	return p
+}
+
+// WithInstance sets URI identifying this occurrence of the problem.
+func (p Problem) WithInstance(uri string) Problem {
+	p = p.clone()
+	p.Instance = uri
+	return p
+}
+
+// WithExtension adds extension member to the problem. Members defined by RFC 7807
+// cannot be overridden this way.
+func (p Problem) WithExtension(name string, value interface{}) Problem {
+	extensions := make(map[string]interface{}, len(p.extensions)+1)
+	for k, v := range p.extensions {
+		extensions[k] = v
+	}
+	extensions[name] = value
+
+	p = p.clone()
+	p.extensions = extensions
+	return p
+}
+
+// clone copies headers of the problem, so problems derived by builders don't share them.
+func (p Problem) clone() Problem {
+	p.rawHeaders = p.rawHeaders.clone()
+	return p
+}
+
+// Extension returns value of extension member.
+func (p Problem) Extension(name string) (interface{}, bool) {
+	value, ok := p.extensions[name]
+	return value, ok
+}
+
+func (p Problem) StatusCode() int { return p.Status }
+
+func (p Problem) Payload() interface{} { return p }
+
+func (p Problem) Error() string { return p.Detail }
+
+// MediaType serves problem with media types registered for problem details.
+func (p Problem) MediaType(mediaType string) string {
+	switch mediaType {
+	case "application/json":
+		return ProblemJSON
+	case "application/xml":
+		return ProblemXML
+	default:
+		return mediaType
+	}
+}
+
+type problemMembers struct {
+	Type     string       `json:"type,omitempty" xml:"type,omitempty"`
+	Title    string       `json:"title" xml:"title"`
+	Detail   string       `json:"detail" xml:"detail"`
+	Status   int          `json:"status" xml:"status"`
+	Instance string       `json:"instance,omitempty" xml:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty" xml:"-"`
+}
+
+func (p Problem) members() problemMembers {
+	return problemMembers{
+		Type:     p.Type,
+		Title:    p.Title,
+		Detail:   p.Detail,
+		Status:   p.Status,
+		Instance: p.Instance,
+		Errors:   p.Errors,
+	}
+}
+
+// extensionNames returns sorted names of extension members, skipping these which would
+// collide with members defined by RFC 7807.
+func (p Problem) extensionNames() []string {
+	names := make([]string, 0, len(p.extensions))
+	for name := range p.extensions {
+		switch name {
+		case "type", "title", "detail", "status", "instance", "errors":
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// MarshalJSON renders extension members next to the standard ones.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(p.members())
+	if err != nil {
+		return nil, err
+	}
+
+	names := p.extensionNames()
+	if len(names) == 0 {
+		return b, nil
+	}
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, name := range names {
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(p.extensions[name])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalXML renders problem in the format from appendix A of RFC 7807.
+func (p Problem) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	document := problemDocument{problemMembers: p.members()}
+	if len(p.Errors) > 0 {
+		document.Errors = &problemErrors{Errors: p.Errors}
+	}
+	for _, name := range p.extensionNames() {
+		document.Extensions = append(document.Extensions, problemExtension{name: name, value: p.extensions[name]})
+	}
+
+	return e.EncodeElement(document, xml.StartElement{Name: xml.Name{Space: problemNamespace, Local: "problem"}})
+}
+
+type problemDocument struct {
+	problemMembers
+	Errors     *problemErrors `xml:"errors,omitempty"`
+	Extensions []problemExtension
+}
+
+type problemErrors struct {
+	Errors []FieldError `xml:"error"`
+}
+
+type problemExtension struct {
+	name  string
+	value interface{}
+}
+
+// MarshalXML renders arrays with an <i> element for every item (RFC 7807, appendix A).
+func (x problemExtension) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(x.name)}}
+
+	v := reflect.ValueOf(x.value)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return e.EncodeElement(x.value, start)
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := e.EncodeElement(v.Index(i).Interface(), xml.StartElement{Name: xml.Name{Local: "i"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// xmlName makes sure extension name is a valid XML element name.
+func xmlName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
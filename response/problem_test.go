@@ -0,0 +1,85 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	problemJSON = "{\"type\":\"https://example.com/probs/out-of-credit\",\"title\":\"You do not have enough credit.\",\"detail\":\"Your current balance is 30, but that costs 50.\",\"status\":403,\"instance\":\"/account/12345/msgs/abc\",\"accounts\":[\"/account/12345\",\"/account/67890\"],\"balance\":30}"
+	problemXML  = "<problem xmlns=\"urn:ietf:rfc:7807\"><type>https://example.com/probs/out-of-credit</type><title>You do not have enough credit.</title><detail>Your current balance is 30, but that costs 50.</detail><status>403</status><instance>/account/12345/msgs/abc</instance><accounts><i>/account/12345</i><i>/account/67890</i></accounts><balance>30</balance></problem>"
+)
+
+func Test_Problem_Defaults(t *testing.T) {
+	p := NewProblem(http.StatusConflict)
+
+	assert.Equal(t, "about:blank", p.Type)
+	assert.Equal(t, "Conflict", p.Title)
+	assert.Equal(t, http.StatusConflict, p.StatusCode())
+}
+
+func Test_Problem_ToJSON(t *testing.T) {
+	assert.Equal(t, problemJSON, toJSON(outOfCredit()))
+}
+
+func Test_Problem_ToXML(t *testing.T) {
+	assert.Equal(t, problemXML, toXML(outOfCredit()))
+}
+
+func Test_Problem_ReservedExtension(t *testing.T) {
+	p := NewProblem(http.StatusConflict).WithExtension("status", 200)
+
+	assert.Equal(t, "{\"type\":\"about:blank\",\"title\":\"Conflict\",\"detail\":\"\",\"status\":409}", toJSON(p))
+}
+
+func Test_Problem_ExtensionsAreCopied(t *testing.T) {
+	base := NewProblem(http.StatusConflict).WithExtension("a", 1)
+	derived := base.WithExtension("b", 2)
+
+	_, ok := base.Extension("b")
+	assert.False(t, ok)
+
+	value, ok := derived.Extension("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func Test_Problem_HeadersAreCopied(t *testing.T) {
+	base := NewProblem(http.StatusConflict)
+	base.WithHeader("x-a", "1")
+	derived := base.WithDetail("conflict")
+	derived.WithHeader("x-b", "2")
+
+	assert.Equal(t, rawHeaders{"x-a": "1"}, base.Header())
+	assert.Equal(t, rawHeaders{"x-a": "1", "x-b": "2"}, derived.Header())
+}
+
+func Test_Problem_StatusConstructorsType(t *testing.T) {
+	assert.Equal(t, "about:blank", NotFound(errors.New("missing")).(Problem).Type)
+	assert.Equal(t, "about:blank", ValidationFailed("invalid").Type)
+}
+
+func Test_Problem_PassedThroughConstructors(t *testing.T) {
+	var err error = outOfCredit()
+
+	assert.Equal(t, http.StatusForbidden, BadRequest(err).StatusCode())
+}
+
+func Test_Problem_MediaType(t *testing.T) {
+	assert.Equal(t, ProblemJSON, MediaType(NotFound(errors.New("missing")), "application/json"))
+	assert.Equal(t, ProblemXML, MediaType(NotFound(errors.New("missing")), "application/xml"))
+	assert.Equal(t, "application/json", MediaType(Ok(), "application/json"))
+}
+
+func outOfCredit() Problem {
+	return NewProblem(http.StatusForbidden).
+		WithType("https://example.com/probs/out-of-credit").
+		WithTitle("You do not have enough credit.").
+		WithDetail("Your current balance is 30, but that costs 50.").
+		WithInstance("/account/12345/msgs/abc").
+		WithExtension("balance", 30).
+		WithExtension("accounts", []string{"/account/12345", "/account/67890"})
+}
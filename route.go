@@ -0,0 +1,269 @@
+package restful
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Route single route registered on the router. Its methods attach optional metadata
+// describing the route in the OpenAPI document.
+type Route struct {
+	method      string
+	pattern     string
+	name        string
+	middlewares []string
+	registry    *routes
+
+	summary   string
+	body      interface{}
+	query     interface{}
+	responses map[int]interface{}
+}
+
+// Method HTTP method of the route.
+func (route *Route) Method() string { return route.method }
+
+// Pattern full routing pattern of the route, including patterns of parent routers.
+func (route *Route) Pattern() string { return route.pattern }
+
+// Name names the route, so its URL can be built with Router.URL. Names must be unique
+// within the router, including routers it's mounted on or mounts, registering the same
+// name twice panics.
+func (route *Route) Name(name string) *Route {
+	if route.name != name {
+		route.registry.checkName(name)
+	}
+
+	route.name = name
+	return route
+}
+
+// WithSummary sets short summary of what the route does.
+func (route *Route) WithSummary(summary string) *Route {
+	route.summary = summary
+	return route
+}
+
+// WithBody declares value of the type decoded from request body, e.g. WithBody(user{}).
+func (route *Route) WithBody(body interface{}) *Route {
+	route.body = body
+	return route
+}
+
+// WithQuery declares struct query parameters are bound to with `query` tags.
+func (route *Route) WithQuery(query interface{}) *Route {
+	route.query = query
+	return route
+}
+
+// WithResponse declares response with the status code. Data is the value passed to
+// response constructors, e.g. response.Ok(data), nil for responses without body. Error
+// statuses are described as problem details.
+func (route *Route) WithResponse(statusCode int, data interface{}) *Route {
+	if route.responses == nil {
+		route.responses = map[int]interface{}{}
+	}
+	route.responses[statusCode] = data
+	return route
+}
+
+func (route *Route) statusCodes() []int {
+	codes := make([]int, 0, len(route.responses))
+	for code := range route.responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	return codes
+}
+
+// routes registry of routes shared by the router and its groups and sub-routers.
+type routes struct {
+	list   []*Route
+	mounts []mountedRoutes
+	// parents registries of routers this one is mounted on.
+	parents []*routes
+}
+
+// mountedRoutes routes of another restful router mounted along prefix, behind
+// middlewares of the parent router.
+type mountedRoutes struct {
+	prefix      string
+	middlewares []string
+	routes      *routes
+}
+
+func (r *routes) add(method, pattern string, middlewares []string) *Route {
+	route := &Route{
+		method:      method,
+		pattern:     pattern,
+		middlewares: append([]string{}, middlewares...),
+		registry:    r,
+	}
+	r.list = append(r.list, route)
+
+	return route
+}
+
+// mount adds routes of the mounted router. Names of its routes must not be used by
+// routes already reachable from the router, otherwise it panics.
+func (r *routes) mount(prefix string, middlewares []string, mounted *routes) {
+	if mounted == r {
+		return
+	}
+
+	for _, route := range mounted.all() {
+		if route.name != "" {
+			r.checkName(route.name)
+		}
+	}
+
+	r.mounts = append(r.mounts, mountedRoutes{
+		prefix:      prefix,
+		middlewares: append([]string{}, middlewares...),
+		routes:      mounted,
+	})
+	mounted.parents = append(mounted.parents, r)
+}
+
+// checkName panics if any route reachable from root routers of the registry, through
+// all routers it's mounted on, already uses the name.
+func (r *routes) checkName(name string) {
+	for _, root := range r.roots() {
+		for _, other := range root.all() {
+			if other.name == name {
+				panic(fmt.Sprintf("restful: route name '%s' is already used by %s %s", name, other.method, other.pattern))
+			}
+		}
+	}
+}
+
+// roots returns registries of top-level routers the registry is reachable from.
+func (r *routes) roots() []*routes {
+	if len(r.parents) == 0 {
+		return []*routes{r}
+	}
+
+	var roots []*routes
+	for _, parent := range r.parents {
+		roots = append(roots, parent.roots()...)
+	}
+
+	return roots
+}
+
+// all returns routes in order of registration, followed by routes of mounted routers
+// with their patterns prefixed.
+func (r *routes) all() []*Route {
+	all := append([]*Route{}, r.list...)
+	for _, mounted := range r.mounts {
+		for _, route := range mounted.routes.all() {
+			prefixed := *route
+			prefixed.pattern = joinPattern(mounted.prefix, route.pattern)
+			prefixed.middlewares = append(append([]string{}, mounted.middlewares...), route.middlewares...)
+			all = append(all, &prefixed)
+		}
+	}
+
+	return all
+}
+
+// joinPattern appends pattern of route to prefix of router it's registered on. Root of
+// sub-router is served along the prefix itself.
+func joinPattern(prefix, pattern string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix != "" && pattern == "/" {
+		return prefix
+	}
+
+	return prefix + pattern
+}
+
+// RouteInfo describes route registered on the router.
+type RouteInfo struct {
+	Method  string
+	Pattern string
+	// Name of the route, empty for routes without name.
+	Name string
+	// Middlewares names of functions applied to the route, in order of execution, e.g.
+	// "gitlab.com/devmint/go-restful/context/paginate.Paginate.func1".
+	Middlewares []string
+}
+
+func (router restfulRouter) RouteInfos() []RouteInfo {
+	var infos []RouteInfo
+	for _, route := range router.routes.all() {
+		infos = append(infos, RouteInfo{
+			Method:      route.method,
+			Pattern:     route.pattern,
+			Name:        route.name,
+			Middlewares: route.middlewares,
+		})
+	}
+
+	return infos
+}
+
+func (router restfulRouter) URL(name string, params ...string) (string, error) {
+	if len(params)%2 != 0 {
+		return "", errors.New("params must be key-value pairs")
+	}
+
+	for _, route := range router.routes.all() {
+		if route.name == name {
+			return buildURL(route.pattern, params)
+		}
+	}
+
+	return "", fmt.Errorf("route '%s' does not exist", name)
+}
+
+// buildURL replaces parameters of routing pattern with escaped values, checking them
+// against regular expressions of parameters. Trailing wildcard is replaced with "*" param.
+func buildURL(pattern string, params []string) (string, error) {
+	values := map[string]string{}
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	var err error
+	used := map[string]bool{}
+	path := pathParam.ReplaceAllStringFunc(pattern, func(param string) string {
+		match := pathParam.FindStringSubmatch(param)
+		value, ok := values[match[1]]
+		switch {
+		case !ok:
+			err = fmt.Errorf("param '%s' is missing", match[1])
+		case match[2] != "" && !regexp.MustCompile("^(?:"+match[2]+")$").MatchString(value):
+			err = fmt.Errorf("param '%s' must match '%s'", match[1], match[2])
+		}
+		used[match[1]] = true
+
+		return url.PathEscape(value)
+	})
+	if strings.HasSuffix(path, "*") {
+		path, used["*"] = strings.TrimSuffix(path, "*")+values["*"], true
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for key := range values {
+		if !used[key] {
+			return "", fmt.Errorf("param '%s' is not a part of pattern '%s'", key, pattern)
+		}
+	}
+
+	return path, nil
+}
+
+// funcName returns name of function, e.g. "gitlab.com/devmint/go-restful/context/paginate.Paginate.func1".
+func funcName(fn interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+}
@@ -0,0 +1,150 @@
+package restful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/context/paginate"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+func Test_JoinPattern(t *testing.T) {
+	assert.Equal(t, "/", joinPattern("", "/"))
+	assert.Equal(t, "/users", joinPattern("/users", "/"))
+	assert.Equal(t, "/users/{id}", joinPattern("/users/", "/{id}"))
+}
+
+func Test_Routes_All(t *testing.T) {
+	mounted := &routes{}
+	mounted.add("GET", "/{id}", []string{"auth"}).WithSummary("show")
+
+	registry := &routes{}
+	registry.add("GET", "/", nil)
+	registry.mount("/users", []string{"log"}, mounted)
+	registry.mount("/", nil, registry)
+
+	all := registry.all()
+
+	assert.Len(t, all, 2)
+	assert.Equal(t, "/", all[0].Pattern())
+	assert.Equal(t, "/users/{id}", all[1].Pattern())
+	assert.Equal(t, "GET", all[1].Method())
+	assert.Equal(t, "show", all[1].summary)
+	assert.Equal(t, []string{"log", "auth"}, all[1].middlewares)
+	assert.Equal(t, "/{id}", mounted.list[0].Pattern())
+}
+
+func namedRouter() Router {
+	handler := func(r request.Request) response.Response { return response.Ok() }
+
+	router := NewRouter(chi.NewMux())
+	router.Use(paginate.Paginate(10, 0))
+	router.Get("/", handler).Name("home")
+	router.Route("/users", func(r Router) {
+		r.Get("/{id:[0-9]+}", handler).Name("user.show")
+		r.With(validUser).Post("/", handler).Name("user.create")
+	})
+
+	files := NewRouter(chi.NewMux())
+	files.Use(auth)
+	files.Get("/{bucket}/*", handler).Name("file.show")
+	router.Mount("/files", files)
+
+	return router
+}
+
+func validUser(r request.Request) (context.Context, response.Response) { return r.Context(), nil }
+
+func auth(r request.Request) (context.Context, response.Response) { return r.Context(), nil }
+
+func Test_Router_RouteInfos(t *testing.T) {
+	paginateName := "gitlab.com/devmint/go-restful/context/paginate.Paginate.func1"
+	validUserName := "gitlab.com/devmint/go-restful.validUser"
+	authName := "gitlab.com/devmint/go-restful.auth"
+
+	assert.Equal(t, []RouteInfo{
+		{Method: "GET", Pattern: "/", Name: "home", Middlewares: []string{paginateName}},
+		{Method: "GET", Pattern: "/users/{id:[0-9]+}", Name: "user.show", Middlewares: []string{paginateName}},
+		{Method: "POST", Pattern: "/users", Name: "user.create", Middlewares: []string{paginateName, validUserName}},
+		{Method: "GET", Pattern: "/files/{bucket}/*", Name: "file.show", Middlewares: []string{paginateName, authName}},
+	}, namedRouter().RouteInfos())
+}
+
+func Test_Router_URL(t *testing.T) {
+	router := namedRouter()
+
+	tests := []struct {
+		name   string
+		params []string
+		url    string
+		err    string
+	}{
+		{name: "home", url: "/"},
+		{name: "user.show", params: []string{"id", "12"}, url: "/users/12"},
+		{name: "user.create", url: "/users"},
+		{name: "file.show", params: []string{"bucket", "a b", "*", "docs/readme.md"}, url: "/files/a%20b/docs/readme.md"},
+		{name: "user.show", params: []string{"id", "abc"}, err: "param 'id' must match '[0-9]+'"},
+		{name: "user.show", err: "param 'id' is missing"},
+		{name: "user.show", params: []string{"id", "12", "slug", "x"}, err: "param 'slug' is not a part of pattern '/users/{id:[0-9]+}'"},
+		{name: "user.show", params: []string{"id"}, err: "params must be key-value pairs"},
+		{name: "user.delete", err: "route 'user.delete' does not exist"},
+	}
+
+	for _, test := range tests {
+		url, err := router.URL(test.name, test.params...)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.url, url)
+	}
+}
+
+func Test_Route_Name_Duplicated(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Get("/users", func(r request.Request) response.Response { return response.Ok() }).Name("users")
+
+	assert.PanicsWithValue(t, "restful: route name 'users' is already used by GET /users", func() {
+		router.Post("/users", func(r request.Request) response.Response { return response.Ok() }).Name("users")
+	})
+}
+
+func Test_Route_Name_DuplicatedAcrossMounts(t *testing.T) {
+	handler := func(r request.Request) response.Response { return response.Ok() }
+
+	router := NewRouter(chi.NewMux())
+	router.Get("/", handler).Name("home")
+	files := NewRouter(chi.NewMux())
+	files.Get("/", handler).Name("home")
+
+	assert.PanicsWithValue(t, "restful: route name 'home' is already used by GET /", func() {
+		router.Mount("/files", files)
+	})
+
+	images := NewRouter(chi.NewMux())
+	router.Mount("/images", images)
+
+	assert.PanicsWithValue(t, "restful: route name 'home' is already used by GET /", func() {
+		images.Get("/", handler).Name("home")
+	})
+	images.Get("/logo", handler).Name("logo")
+	assert.PanicsWithValue(t, "restful: route name 'logo' is already used by GET /images/logo", func() {
+		router.Post("/", handler).Name("logo")
+	})
+}
+
+func Test_Router_MountedIsChiRoutes(t *testing.T) {
+	files := NewRouter(chi.NewMux())
+	files.Get("/{name}", func(r request.Request) response.Response { return response.Ok() })
+
+	plain := chi.NewMux()
+	plain.Mount("/files", files)
+
+	assert.True(t, plain.Match(chi.NewRouteContext(), "GET", "/files/readme.md"))
+	assert.False(t, plain.Match(chi.NewRouteContext(), "GET", "/images/logo.png"))
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+	"gitlab.com/devmint/go-restful/codec"
+	"gitlab.com/devmint/go-restful/openapi"
 	"gitlab.com/devmint/go-restful/request"
 )
 
@@ -25,53 +27,129 @@ type Router interface {
 
 	Mount(pattern string, h http.Handler)
 
-	// HTTP-method routing along `pattern`
-	Connect(pattern string, h request.RestfulHandler)
-	Delete(pattern string, h request.RestfulHandler)
-	Get(pattern string, h request.RestfulHandler)
-	Head(pattern string, h request.RestfulHandler)
-	Options(pattern string, h request.RestfulHandler)
-	Patch(pattern string, h request.RestfulHandler)
-	Post(pattern string, h request.RestfulHandler)
-	Put(pattern string, h request.RestfulHandler)
-	Trace(pattern string, h request.RestfulHandler)
+	// Resource mounts sub-Router along `pattern` serving actions implemented by
+	// controller (see Lister, Creator, Shower, Updater, Patcher and Deleter) on `pattern`
+	// and `pattern`/{id}. List is paginated, other methods get Method Not Allowed. It
+	// panics when controller implements none of them.
+	Resource(pattern string, controller interface{}) Router
+
+	// HTTP-method routing along `pattern`. Returned route can be described for the
+	// OpenAPI document.
+	Connect(pattern string, h request.RestfulHandler) *Route
+	Delete(pattern string, h request.RestfulHandler) *Route
+	Get(pattern string, h request.RestfulHandler) *Route
+	Head(pattern string, h request.RestfulHandler) *Route
+	Options(pattern string, h request.RestfulHandler) *Route
+	Patch(pattern string, h request.RestfulHandler) *Route
+	Post(pattern string, h request.RestfulHandler) *Route
+	Put(pattern string, h request.RestfulHandler) *Route
+	Trace(pattern string, h request.RestfulHandler) *Route
+
+	// NotFound sets handler for paths matching no route, by default it responds with
+	// Not Found problem.
+	NotFound(h request.RestfulHandler)
+
+	// MethodNotAllowed sets handler for paths routed for other methods only, by default
+	// it responds with Method Not Allowed problem listing allowed methods in the Allow
+	// header (see AllowedMethods).
+	MethodNotAllowed(h request.RestfulHandler)
+
+	// OpenAPI describes routes registered on the router, its groups, sub-routers and
+	// mounted restful routers. Serve it with openapi.Handler(router.OpenAPI).
+	OpenAPI() *openapi.Document
+
+	// RouteInfos lists routes registered on the router, its groups, sub-routers and
+	// mounted restful routers.
+	RouteInfos() []RouteInfo
+
+	// URL builds path of the route with the given name, replacing its parameters with
+	// values from key-value pairs, e.g. URL("user.show", "id", "12").
+	URL(name string, params ...string) (string, error)
 }
 
 type restfulRouter struct {
-	r chi.Router
+	r         chi.Router
+	options   request.Options
+	fallbacks *fallbacks
+	routes    *routes
+	prefix    string
+	info      openapi.Info
+
+	// middlewares names of middlewares applied to routes registered on this router.
+	middlewares *[]string
 }
 
 type RouterOptions struct {
+	// Validator checks structs decoded from requests of this router, it takes precedence
+	// over Request.Validator.
 	Validator request.RequestBodyValidation
+
+	// Request configures decoding of requests and rendering of responses by handlers of
+	// this router only, e.g. body limits, recovery of panics, entity tags and errors.
+	Request request.Options
+
+	// AutoHead answers HEAD requests for paths without HEAD handler with their GET
+	// handler, dropping body of the response. The plain router must have no routes yet.
+	AutoHead bool
+
+	// AutoOptions answers OPTIONS requests for paths without OPTIONS handler with
+	// 204 No Content listing allowed methods in the Allow header. The plain router must
+	// have no routes yet.
+	AutoOptions bool
+
+	// API title and version of the OpenAPI document.
+	API openapi.Info
 }
 
 func NewRouter(plainRouter chi.Router, options ...RouterOptions) Router {
+	router := restfulRouter{r: plainRouter, fallbacks: newFallbacks(), routes: &routes{}, middlewares: &[]string{}}
 	if nil != options && len(options) == 1 {
 		singleOption := options[0]
+		router.options = singleOption.Request
 		if singleOption.Validator != nil {
-			request.RegisterValidator(singleOption.Validator)
+			router.options.Validator = singleOption.Validator
+		}
+		router.info = singleOption.API
+
+		if singleOption.AutoHead || singleOption.AutoOptions {
+			plainRouter.Use(autoMethods{head: singleOption.AutoHead, options: singleOption.AutoOptions}.handle)
 		}
 	}
 
-	return restfulRouter{r: plainRouter}
+	router.handleFallbacks()
+
+	return router
+}
+
+// RegisterCodec makes restful handlers able to decode request bodies from and render
+// responses to the given media type, e.g. YAML or MessagePack. JSON and XML are
+// registered by default.
+func RegisterCodec(mediaType string, c codec.Codec) {
+	codec.Register(mediaType, c)
 }
 
 func (router restfulRouter) Use(middlewares ...request.ContextHandler) {
 	var httpMiddlewares []func(http.Handler) http.Handler
 	for _, middleware := range middlewares {
-		httpMiddlewares = append(httpMiddlewares, request.HandleContext(middleware))
+		httpMiddlewares = append(httpMiddlewares, request.HandleContext(middleware, router.options))
+		*router.middlewares = append(*router.middlewares, funcName(middleware))
 	}
 
 	router.r.Use(httpMiddlewares...)
 }
 
 func (router restfulRouter) With(middlewares ...request.ContextHandler) Router {
+	names := append([]string{}, *router.middlewares...)
 	var httpMiddlewares []func(http.Handler) http.Handler
 	for _, middleware := range middlewares {
-		httpMiddlewares = append(httpMiddlewares, request.HandleContext(middleware))
+		httpMiddlewares = append(httpMiddlewares, request.HandleContext(middleware, router.options))
+		names = append(names, funcName(middleware))
 	}
 
-	return restfulRouter{r: router.r.With(httpMiddlewares...)}
+	newRouter := router
+	newRouter.r = router.r.With(httpMiddlewares...)
+	newRouter.middlewares = &names
+	return newRouter
 }
 
 func (router restfulRouter) Group(fn func(r Router)) Router {
@@ -83,7 +161,12 @@ func (router restfulRouter) Group(fn func(r Router)) Router {
 }
 
 func (router restfulRouter) Route(pattern string, fn func(r Router)) Router {
-	newRouter := restfulRouter{r: chi.NewMux()}
+	newRouter := router
+	newRouter.r = chi.NewMux()
+	newRouter.prefix = joinPattern(router.prefix, pattern)
+	middlewares := append([]string{}, *router.middlewares...)
+	newRouter.middlewares = &middlewares
+	newRouter.handleFallbacks()
 	if fn != nil {
 		fn(newRouter)
 	}
@@ -94,46 +177,75 @@ func (router restfulRouter) Route(pattern string, fn func(r Router)) Router {
 
 func (router restfulRouter) Mount(pattern string, h http.Handler) {
 	router.r.Mount(pattern, h)
+	if mounted, ok := h.(restfulRouter); ok {
+		router.routes.mount(joinPattern(router.prefix, pattern), *router.middlewares, mounted.routes)
+	}
+}
+
+func (router restfulRouter) method(method string, pattern string, h request.RestfulHandler) *Route {
+	router.r.MethodFunc(method, pattern, request.HandleAction(h, router.options))
+	return router.routes.add(method, joinPattern(router.prefix, pattern), *router.middlewares)
 }
 
-func (router restfulRouter) method(method string, pattern string, h request.RestfulHandler) {
-	router.r.MethodFunc(method, pattern, request.HandleAction(h))
+func (router restfulRouter) Connect(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodConnect, pattern, h)
 }
 
-func (router restfulRouter) Connect(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodConnect, pattern, h)
+func (router restfulRouter) Delete(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodDelete, pattern, h)
 }
 
-func (router restfulRouter) Delete(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodDelete, pattern, h)
+func (router restfulRouter) Get(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodGet, pattern, h)
 }
 
-func (router restfulRouter) Get(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodGet, pattern, h)
+func (router restfulRouter) Head(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodHead, pattern, h)
 }
 
-func (router restfulRouter) Head(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodHead, pattern, h)
+func (router restfulRouter) Options(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodOptions, pattern, h)
 }
 
-func (router restfulRouter) Options(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodOptions, pattern, h)
+func (router restfulRouter) Patch(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodPatch, pattern, h)
 }
 
-func (router restfulRouter) Patch(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodPatch, pattern, h)
+func (router restfulRouter) Post(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodPost, pattern, h)
 }
 
-func (router restfulRouter) Post(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodPost, pattern, h)
+func (router restfulRouter) Put(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodPut, pattern, h)
 }
 
-func (router restfulRouter) Put(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodPut, pattern, h)
+func (router restfulRouter) Trace(pattern string, h request.RestfulHandler) *Route {
+	return router.method(http.MethodTrace, pattern, h)
 }
 
-func (router restfulRouter) Trace(pattern string, h request.RestfulHandler) {
-	router.method(http.MethodTrace, pattern, h)
+// handleFallbacks routes requests matching no route of the underlying router to the
+// shared fallbacks.
+func (router restfulRouter) handleFallbacks() {
+	router.r.NotFound(request.HandleAction(router.fallbacks.handleNotFound, router.options))
+	router.r.MethodNotAllowed(request.HandleAction(router.fallbacks.handleMethodNotAllowed, router.options))
+}
+
+func (router restfulRouter) NotFound(h request.RestfulHandler) {
+	router.fallbacks.notFound = h
+}
+
+func (router restfulRouter) MethodNotAllowed(h request.RestfulHandler) {
+	router.fallbacks.methodNotAllowed = h
+}
+
+// Routes, Middlewares and Match make restful router a chi.Routes, so mounting routers
+// keep their routing tree walkable.
+func (router restfulRouter) Routes() []chi.Route { return router.r.Routes() }
+
+func (router restfulRouter) Middlewares() chi.Middlewares { return router.r.Middlewares() }
+
+func (router restfulRouter) Match(rctx *chi.Context, method, path string) bool {
+	return router.r.Match(rctx, method, path)
 }
 
 func (router restfulRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
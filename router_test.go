@@ -5,12 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi"
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/codec"
 	"gitlab.com/devmint/go-restful/request"
 	"gitlab.com/devmint/go-restful/response"
 )
@@ -95,6 +100,62 @@ func Test_PostRoute_CustomValidator(t *testing.T) {
 	assert.Contains(t, response.Body.String(), "some-random-error")
 }
 
+func Test_PostRoute_ValidatorPerRouter(t *testing.T) {
+	handler := func(r request.Request) response.Response {
+		body := customBody{}
+		if err := r.Body(&body); err != nil {
+			return response.BadRequest(err)
+		}
+
+		return response.Ok(body.A)
+	}
+
+	customRouter := NewRouter(chi.NewMux(), RouterOptions{Validator: customValidator{}})
+	customRouter.Post("/", handler)
+	defaultRouter := NewRouter(chi.NewMux())
+	defaultRouter.Post("/", handler)
+
+	for i := 0; i < 10; i++ {
+		t.Run("custom", func(t *testing.T) {
+			t.Parallel()
+
+			response := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":16}"))
+			customRouter.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+		t.Run("default", func(t *testing.T) {
+			t.Parallel()
+
+			response := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":16}"))
+			defaultRouter.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusOK, response.Code)
+		})
+	}
+}
+
+func Test_PostRoute_RequestOptionsValidator(t *testing.T) {
+	router := NewRouter(chi.NewMux(), RouterOptions{Request: request.Options{Validator: customValidator{}}})
+	router.Post("/", func(r request.Request) response.Response {
+		body := customBody{}
+		if err := r.Body(&body); err != nil {
+			return response.BadRequest(err)
+		}
+
+		return response.Ok(body.A)
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("{\"a\":16}"))
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), "some-random-error")
+}
+
 func Test_GroupRoute(t *testing.T) {
 	router := NewRouter(chi.NewMux())
 	router.Group(func(r Router) {
@@ -131,6 +192,31 @@ func Test_RouteNested(t *testing.T) {
 	assert.Equal(t, "{\"data\":\"b\"}", responseB.Body.String())
 }
 
+func Test_RegisterCodec(t *testing.T) {
+	RegisterCodec("text/plain", plainCodec{})
+	t.Cleanup(func() { codec.Unregister("text/plain") })
+
+	router := NewRouter(chi.NewMux())
+	router.Post("/", func(r request.Request) response.Response {
+		var body string
+		if err := r.Body(&body); err != nil {
+			return response.BadRequest(err)
+		}
+
+		return response.Ok(body)
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/", strings.NewReader("lorem ipsum"))
+	request.Header.Set("content-type", "text/plain")
+	request.Header.Set("accept", "text/plain")
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/plain", response.Header().Get("content-type"))
+	assert.Contains(t, response.Body.String(), "Data:lorem ipsum")
+}
+
 func Benchmark_GetRoute(b *testing.B) {
 	router := NewRouter(chi.NewMux())
 	router.Get("/", func(r request.Request) response.Response {
@@ -179,6 +265,19 @@ func Benchmark_PostRoute_Validation(b *testing.B) {
 	}
 }
 
+type plainCodec struct{}
+
+func (plainCodec) Encode(w io.Writer, v interface{}) error {
+	_, err := fmt.Fprintf(w, "%+v", v)
+	return err
+}
+
+func (plainCodec) Decode(r io.Reader, v interface{}) error {
+	b, err := ioutil.ReadAll(r)
+	*(v.(*string)) = string(b)
+	return err
+}
+
 type customValidator struct{}
 
 func (v customValidator) Struct(s interface{}) error { return errors.New("some-random-error") }
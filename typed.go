@@ -0,0 +1,56 @@
+package restful
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+// TypedHandler handles input decoded from request and returns output rendered with
+// response.Ok, or an error.
+type TypedHandler[In, Out any] func(ctx context.Context, req request.Request, in In) (Out, error)
+
+// Typed adapts handler working with Go types into RestfulHandler. Input is bound from
+// query string of GET, HEAD and DELETE requests and decoded from body of the others,
+// then validated. Use struct{} as In for handlers without input. Output is wrapped in
+// response.Ok, unless it's a response.Response itself. Returned errors are described
+// by Request.Error, using error map of the router.
+func Typed[In, Out any](handler TypedHandler[In, Out]) request.RestfulHandler {
+	return func(r request.Request) response.Response {
+		var in In
+		if err := decodeInput(r, &in); err != nil {
+			return response.BadRequest(err)
+		}
+
+		out, err := handler(r.Context(), r, in)
+		if err != nil {
+			return r.Error(err)
+		}
+
+		if res, ok := any(out).(response.Response); ok {
+			return res
+		}
+
+		return response.Ok(out)
+	}
+}
+
+func decodeInput[In any](r request.Request, in *In) error {
+	t := reflect.TypeOf(in).Elem()
+	if t.Kind() == reflect.Struct && t.NumField() == 0 {
+		return nil
+	}
+
+	switch r.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		return r.BindQuery(in)
+	default:
+		return r.Body(in)
+	}
+}
@@ -0,0 +1,105 @@
+package restful
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/devmint/go-restful/request"
+	"gitlab.com/devmint/go-restful/response"
+)
+
+type typedInput struct {
+	Name string `json:"name" query:"name" validate:"required"`
+}
+
+var errGreetingMissing = errors.New("greeting of ghost is missing in table greetings")
+
+type typedOutput struct {
+	Greeting string `json:"greeting"`
+}
+
+func greet(ctx context.Context, r request.Request, in typedInput) (typedOutput, error) {
+	switch in.Name {
+	case "nobody":
+		return typedOutput{}, response.NewProblem(http.StatusNotFound).WithDetail("nobody is not here")
+	case "secret":
+		return typedOutput{}, errors.New("database password is 1234")
+	case "ghost":
+		return typedOutput{}, fmt.Errorf("loading: %w", errGreetingMissing)
+	}
+
+	return typedOutput{Greeting: "hello " + in.Name}, nil
+}
+
+func Test_Typed(t *testing.T) {
+	errs := &response.ErrorMap{}
+	errs.MapError(errGreetingMissing, http.StatusNotFound)
+
+	router := NewRouter(chi.NewMux(), RouterOptions{Request: request.Options{Errors: errs}})
+	router.Get("/", Typed(greet))
+	router.Post("/", Typed(greet))
+
+	tests := []struct {
+		method, target, body string
+		status               int
+		contains             string
+	}{
+		{"GET", "/?name=john", "", http.StatusOK, `{"data":{"greeting":"hello john"}}`},
+		{"POST", "/", `{"name":"jane"}`, http.StatusOK, `{"data":{"greeting":"hello jane"}}`},
+		{"POST", "/", `{"name":`, http.StatusBadRequest, `"status":400`},
+		{"POST", "/", `{}`, http.StatusUnprocessableEntity, `"field":"name"`},
+		{"GET", "/", "", http.StatusUnprocessableEntity, `"field":"name"`},
+		{"GET", "/?name=nobody", "", http.StatusNotFound, `"detail":"nobody is not here"`},
+		{"GET", "/?name=secret", "", http.StatusInternalServerError, `"detail":"the server encountered an unexpected condition"`},
+		{"GET", "/?name=ghost", "", http.StatusNotFound, `"detail":"Not Found"`},
+	}
+
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, test.status, response.Code, fmt.Sprint(test))
+		assert.Contains(t, response.Body.String(), test.contains)
+		assert.NotContains(t, response.Body.String(), "1234")
+		assert.NotContains(t, response.Body.String(), "greetings")
+	}
+}
+
+func Test_Typed_WithoutInput(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Post("/", Typed(func(ctx context.Context, r request.Request, _ struct{}) (response.Response, error) {
+		return response.Accepted(), nil
+	}))
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/", nil)
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusAccepted, response.Code)
+}
+
+func Test_Typed_Slices(t *testing.T) {
+	router := NewRouter(chi.NewMux())
+	router.Put("/", Typed(func(ctx context.Context, r request.Request, in []int) (int, error) {
+		sum := 0
+		for _, n := range in {
+			sum += n
+		}
+		return sum, nil
+	}))
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("PUT", "/", strings.NewReader("[1,2,3]"))
+	router.ServeHTTP(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, `{"data":6}`, response.Body.String())
+}